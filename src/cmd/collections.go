@@ -0,0 +1,76 @@
+// Package cmd
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-24
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/db"
+	"github.com/teocci/go-hynix-3d-viewer/src/gisdata"
+)
+
+// defaultCollectionFiles lists the legacy collection files. The updated file comes first
+// so its version wins when both contain the same UUID.
+var defaultCollectionFiles = []string{
+	"./web/json/updated-collections.json",
+	"./web/json/collections.json",
+}
+
+var (
+	collectionsCmd = &cobra.Command{
+		Use:   "collections",
+		Short: "Manage GIS collections",
+	}
+
+	collectionsImportCmd = &cobra.Command{
+		Use:   "import [files...]",
+		Short: "Import collections from JSON files into the database",
+		Long: `Import collections from JSON files into the database under a provider.
+Collections whose UUID already exists are skipped, so the import can be run again safely.
+Without arguments the legacy files under web/json are imported.`,
+		RunE: runCollectionsImport,
+	}
+
+	importProvider string
+)
+
+func init() {
+	collectionsImportCmd.Flags().StringVar(&importProvider, "provider", "", "UUID of the provider that will own the collections")
+	_ = collectionsImportCmd.MarkFlagRequired("provider")
+
+	collectionsCmd.AddCommand(collectionsImportCmd)
+	app.AddCommand(collectionsCmd)
+}
+
+func runCollectionsImport(ccmd *cobra.Command, args []string) error {
+	files := args
+	if len(files) == 0 {
+		files = defaultCollectionFiles
+	}
+
+	if _, err := db.GetProviderDetails(importProvider); err != nil {
+		return fmt.Errorf("provider %s: %w", importProvider, err)
+	}
+
+	total := 0
+	for _, file := range files {
+		collections, err := gisdata.LoadCollectionsFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to load %s: %w", file, err)
+		}
+
+		imported, err := db.ImportGISCollections(importProvider, collections)
+		if err != nil {
+			return fmt.Errorf("failed to import %s: %w", file, err)
+		}
+
+		fmt.Printf("Imported %d of %d collections from %s\n", imported, len(collections), file)
+		total += imported
+	}
+
+	fmt.Printf("Imported %d collections\n", total)
+	return nil
+}
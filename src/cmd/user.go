@@ -0,0 +1,207 @@
+// Package cmd
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-20
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/db"
+)
+
+const minPasswordLength = 8
+
+var (
+	userCmd = &cobra.Command{
+		Use:   "user",
+		Short: "Manage user accounts",
+		Long:  `Create, list and update the accounts stored in the application database.`,
+	}
+
+	userAddCmd = &cobra.Command{
+		Use:   "add <username>",
+		Short: "Create a new user",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runUserAdd,
+	}
+
+	userListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List all users",
+		Args:  cobra.NoArgs,
+		RunE:  runUserList,
+	}
+
+	userPasswdCmd = &cobra.Command{
+		Use:   "passwd <username>",
+		Short: "Change the password of a user",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runUserPasswd,
+	}
+
+	userDisableCmd = &cobra.Command{
+		Use:   "disable <username>",
+		Short: "Disable a user and end their sessions",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runUserDisable,
+	}
+
+	userRoleCmd = &cobra.Command{
+		Use:   "role <username> <admin|editor|viewer>",
+		Short: "Change the global role of a user",
+		Args:  cobra.ExactArgs(2),
+		RunE:  runUserRole,
+	}
+
+	userName     string
+	userRole     string
+	userPassword string
+	userEnable   bool
+
+	ErrPasswordTooShort = fmt.Errorf("password must be at least %d characters", minPasswordLength)
+	ErrEmptyPassword    = errors.New("password is required")
+)
+
+func init() {
+	userAddCmd.Flags().StringVarP(&userName, "name", "n", "", "Display name of the user (defaults to the username)")
+	userAddCmd.Flags().StringVar(&userRole, "role", string(db.RoleViewer), "Role of the user: admin, editor or viewer")
+	userAddCmd.Flags().StringVar(&userPassword, "password", "", "Password of the user (prompted when omitted)")
+
+	userPasswdCmd.Flags().StringVar(&userPassword, "password", "", "New password (prompted when omitted)")
+
+	userDisableCmd.Flags().BoolVar(&userEnable, "enable", false, "Re-enable the user instead")
+
+	userCmd.AddCommand(userAddCmd, userListCmd, userPasswdCmd, userDisableCmd, userRoleCmd)
+	app.AddCommand(userCmd)
+}
+
+func runUserAdd(ccmd *cobra.Command, args []string) error {
+	username := strings.TrimSpace(args[0])
+
+	role, ok := db.ParseRole(userRole)
+	if !ok {
+		return db.ErrInvalidRole
+	}
+
+	name := strings.TrimSpace(userName)
+	if name == "" {
+		name = username
+	}
+
+	password, err := readPassword(userPassword)
+	if err != nil {
+		return err
+	}
+
+	user, err := db.CreateUser(name, username, password, role)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Created user %s (%s) with role %s\n", user.Username, user.UUID, user.Role)
+	return nil
+}
+
+func runUserList(ccmd *cobra.Command, args []string) error {
+	users, err := db.ListUsers()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "USERNAME\tNAME\tROLE\tSTATUS\tUUID")
+	for _, u := range users {
+		status := "active"
+		if u.Disabled {
+			status = "disabled"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", u.Username, u.Name, u.Role, status, u.UUID)
+	}
+
+	return w.Flush()
+}
+
+func runUserPasswd(ccmd *cobra.Command, args []string) error {
+	user, err := db.GetUserByUsername(args[0])
+	if err != nil {
+		return fmt.Errorf("user %s: %w", args[0], err)
+	}
+
+	password, err := readPassword(userPassword)
+	if err != nil {
+		return err
+	}
+
+	if err := db.ChangeUserPassword(user.UUID, password); err != nil {
+		return err
+	}
+
+	fmt.Printf("Password changed for %s\n", user.Username)
+	return nil
+}
+
+func runUserDisable(ccmd *cobra.Command, args []string) error {
+	user, err := db.GetUserByUsername(args[0])
+	if err != nil {
+		return fmt.Errorf("user %s: %w", args[0], err)
+	}
+
+	if _, err := db.SetUserDisabled(user.UUID, !userEnable); err != nil {
+		return err
+	}
+
+	state := "disabled"
+	if userEnable {
+		state = "enabled"
+	}
+	fmt.Printf("User %s %s\n", user.Username, state)
+	return nil
+}
+
+func runUserRole(ccmd *cobra.Command, args []string) error {
+	user, err := db.GetUserByUsername(args[0])
+	if err != nil {
+		return fmt.Errorf("user %s: %w", args[0], err)
+	}
+
+	role, ok := db.ParseRole(args[1])
+	if !ok {
+		return db.ErrInvalidRole
+	}
+
+	if _, err := db.UpdateUserRole(user.UUID, role); err != nil {
+		return err
+	}
+
+	fmt.Printf("User %s is now %s\n", user.Username, role)
+	return nil
+}
+
+// readPassword returns the flag value or prompts for the password on stdin.
+func readPassword(flag string) (string, error) {
+	password := flag
+	if password == "" {
+		fmt.Print("Password: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return "", ErrEmptyPassword
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+
+	if password == "" {
+		return "", ErrEmptyPassword
+	}
+	if len(password) < minPasswordLength {
+		return "", ErrPasswordTooShort
+	}
+
+	return password, nil
+}
@@ -0,0 +1,36 @@
+// Package config
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-18
+package config
+
+import "time"
+
+const (
+	AuthAlgorithmHS256 = "HS256"
+	AuthAlgorithmRS256 = "RS256"
+	AuthAlgorithmEdDSA = "EdDSA"
+
+	defaultAccessTTL  = "15m"
+	defaultRefreshTTL = "168h"
+)
+
+// AuthKey describes a single JWT signing key.
+// HS256 keys use Secret, RS256 and EdDSA keys use PEM files.
+// A verification-only key omits PrivateKey and keeps tokens it signed valid during a rotation.
+type AuthKey struct {
+	KID        string `json:"kid"`
+	Algorithm  string `json:"algorithm"`
+	Secret     string `json:"secret,omitempty"`
+	PrivateKey string `json:"privateKey,omitempty"`
+	PublicKey  string `json:"publicKey,omitempty"`
+}
+
+// AuthSetup holds the token settings.
+// When Keys is empty, Secret (or JWT_SECRET) is used as a single HS256 key.
+type AuthSetup struct {
+	Secret     string        `json:"secret,omitempty"`
+	ActiveKID  string        `json:"activeKid,omitempty"`
+	Keys       []AuthKey     `json:"keys,omitempty"`
+	AccessTTL  time.Duration `json:"accessTTL"`
+	RefreshTTL time.Duration `json:"refreshTTL"`
+}
@@ -0,0 +1,36 @@
+// Package config
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-10
+package config
+
+import "time"
+
+const (
+	defaultCacheTTL         = 10 * time.Minute
+	defaultCacheMaxMemoryMB = 512
+)
+
+// CacheSetup holds the settings of the upstream geometry cache.
+// Entries older than TTL are fetched again. MaxMemoryMB bounds the in-memory LRU by the
+// encoded size of its entries. When Directory is set, entries are also kept on disk there
+// and survive a restart. With Stream, nodes and links that are not cached are streamed
+// from the backend to the client element by element instead of being loaded into the cache
+// first, which keeps memory flat for very large networks.
+type CacheSetup struct {
+	TTL         time.Duration `json:"ttl"`
+	MaxMemoryMB int           `json:"maxMemoryMB"`
+	Directory   string        `json:"directory,omitempty"`
+	Stream      bool          `json:"stream"`
+}
+
+// WithDefaults fills the unset fields.
+func (cs CacheSetup) WithDefaults() CacheSetup {
+	if cs.TTL <= 0 {
+		cs.TTL = defaultCacheTTL
+	}
+	if cs.MaxMemoryMB <= 0 {
+		cs.MaxMemoryMB = defaultCacheMaxMemoryMB
+	}
+
+	return cs
+}
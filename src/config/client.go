@@ -0,0 +1,72 @@
+// Package config
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-09
+package config
+
+import "time"
+
+const (
+	defaultClientTimeout       = 60 * time.Second
+	defaultClientDialTimeout   = 5 * time.Second
+	defaultClientIdleConns     = 16
+	defaultClientIdleTimeout   = 90 * time.Second
+	defaultClientRetries       = 2
+	defaultClientRetryBackoff  = 200 * time.Millisecond
+	defaultClientMaxBackoff    = 5 * time.Second
+	defaultClientBreakerLimit  = 5
+	defaultClientBreakerPeriod = 30 * time.Second
+)
+
+// ClientSetup tunes the HTTP client used to call a GIS backend.
+// Timeout bounds the wait for the response headers; the body can take longer, so large
+// geometry can be streamed, and is only bounded by the context of the call. Retries
+// applies to idempotent calls that failed with a network error or a 5xx status, waiting
+// RetryBackoff doubled on every attempt up to MaxBackoff. After BreakerThreshold failed
+// calls in a row the backend is considered down and calls fail fast for BreakerCooldown.
+type ClientSetup struct {
+	Timeout             time.Duration `json:"timeout"`
+	DialTimeout         time.Duration `json:"dialTimeout"`
+	MaxIdleConnsPerHost int           `json:"maxIdleConnsPerHost"`
+	IdleConnTimeout     time.Duration `json:"idleConnTimeout"`
+	Retries             int           `json:"retries"`
+	RetryBackoff        time.Duration `json:"retryBackoff"`
+	MaxBackoff          time.Duration `json:"maxBackoff"`
+	BreakerThreshold    int           `json:"breakerThreshold"`
+	BreakerCooldown     time.Duration `json:"breakerCooldown"`
+}
+
+// WithDefaults fills the unset fields. A negative Retries disables retrying.
+func (cs ClientSetup) WithDefaults() ClientSetup {
+	if cs.Timeout <= 0 {
+		cs.Timeout = defaultClientTimeout
+	}
+	if cs.DialTimeout <= 0 {
+		cs.DialTimeout = defaultClientDialTimeout
+	}
+	if cs.MaxIdleConnsPerHost <= 0 {
+		cs.MaxIdleConnsPerHost = defaultClientIdleConns
+	}
+	if cs.IdleConnTimeout <= 0 {
+		cs.IdleConnTimeout = defaultClientIdleTimeout
+	}
+	if cs.Retries == 0 {
+		cs.Retries = defaultClientRetries
+	}
+	if cs.Retries < 0 {
+		cs.Retries = 0
+	}
+	if cs.RetryBackoff <= 0 {
+		cs.RetryBackoff = defaultClientRetryBackoff
+	}
+	if cs.MaxBackoff <= 0 {
+		cs.MaxBackoff = defaultClientMaxBackoff
+	}
+	if cs.BreakerThreshold <= 0 {
+		cs.BreakerThreshold = defaultClientBreakerLimit
+	}
+	if cs.BreakerCooldown <= 0 {
+		cs.BreakerCooldown = defaultClientBreakerPeriod
+	}
+
+	return cs
+}
@@ -18,23 +18,33 @@ import (
 )
 
 type APIServer struct {
-	Host     string `json:"host"`
-	Port     int    `json:"port"`
-	Protocol string `json:"protocol,omitempty"`
+	Host     string      `json:"host"`
+	Port     int         `json:"port"`
+	Protocol string      `json:"protocol,omitempty"`
+	Client   ClientSetup `json:"client"`
 }
 
 type WebServer struct {
 	Port int `json:"port"`
 }
 
+// ProfileData describes a GIS backend. CRS is the coordinate reference system of the
+// geometry it serves, such as "EPSG:5186"; it is empty when unknown.
 type ProfileData struct {
-	API APIServer `json:"endpoints"`
+	API    APIServer   `json:"endpoints"`
+	Source SourceSetup `json:"source"`
+	CRS    string      `json:"crs,omitempty"`
+	Trace  TraceSetup  `json:"trace"`
+	Types  TypesSetup  `json:"types"`
 }
 
 type ServerSetup struct {
 	Web      WebServer              `json:"web"`
 	Profile  string                 `json:"profile"`
 	Profiles map[string]ProfileData `json:"profiles"`
+	Auth     AuthSetup              `json:"auth"`
+	Security SecuritySetup          `json:"security"`
+	Cache    CacheSetup             `json:"cache"`
 	Config   string                 `json:"-"`
 }
 
@@ -78,6 +88,8 @@ func LoadConfigFile(file string) error {
 
 	// Set default values
 	v.SetDefault("profile", defaultProfile)
+	v.SetDefault("auth.accessTTL", defaultAccessTTL)
+	v.SetDefault("auth.refreshTTL", defaultRefreshTTL)
 
 	// Read in the configuration file
 	if err := v.ReadInConfig(); err != nil {
@@ -89,6 +101,8 @@ func LoadConfigFile(file string) error {
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	_ = v.BindEnv("profile", "PROFILE")
 	_ = v.BindEnv("web.port", "WEB_SERVER_PORT")
+	_ = v.BindEnv("auth.secret", "JWT_SECRET")
+	_ = v.BindEnv("security.masterKey", "MASTER_KEY")
 
 	log.Printf("Profile-aa: %v\n", v.Get("profile"))
 	log.Printf("Web Port-aa: %v\n", v.Get("web.port"))
@@ -204,6 +218,8 @@ func Watch() {
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	_ = v.BindEnv("profile", "PROFILE")
 	_ = v.BindEnv("web.port", "WEB_SERVER_PORT")
+	_ = v.BindEnv("auth.secret", "JWT_SECRET")
+	_ = v.BindEnv("security.masterKey", "MASTER_KEY")
 
 	// Watch for changes
 	v.WatchConfig()
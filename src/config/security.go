@@ -0,0 +1,10 @@
+// Package config
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-21
+package config
+
+// SecuritySetup holds the secrets used to protect data at rest.
+// MasterKey can be any high-entropy string; it can also come from MASTER_KEY.
+type SecuritySetup struct {
+	MasterKey string `json:"masterKey,omitempty"`
+}
@@ -0,0 +1,19 @@
+// Package config
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-12
+package config
+
+const (
+	SourceREST = "rest"
+	SourceFile = "file"
+)
+
+// SourceSetup selects where a profile reads its networks from. Kind is SourceREST, the
+// default, for the GIS API described by the api block, or SourceFile for JSON files in
+// Directory named network-<uuid>-nodes.json and network-<uuid>-links.json. Fallback names
+// the files served for networks that have none of their own, such as "dummy".
+type SourceSetup struct {
+	Kind      string `json:"kind,omitempty"`
+	Directory string `json:"directory,omitempty"`
+	Fallback  string `json:"fallback,omitempty"`
+}
@@ -0,0 +1,10 @@
+// Package config
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-02
+package config
+
+// TraceSetup holds the network trace settings of a profile.
+// BarrierTypes lists the node type codes (valves, for example) a flow trace stops at.
+type TraceSetup struct {
+	BarrierTypes []int `json:"barrierTypes,omitempty"`
+}
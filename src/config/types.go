@@ -0,0 +1,22 @@
+// Package config
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-15
+package config
+
+// TypeSetup describes a node or link type code: how the viewer names, groups and draws it.
+// Color is a CSS hex colour and Icon the id of an icon of the viewer. Visible defaults to true.
+type TypeSetup struct {
+	Code     int    `json:"code"`
+	Name     string `json:"name"`
+	Category string `json:"category,omitempty"`
+	Color    string `json:"color,omitempty"`
+	Icon     string `json:"icon,omitempty"`
+	Visible  *bool  `json:"visible,omitempty"`
+}
+
+// TypesSetup holds the node and link types of a profile. They override the types served
+// by the GIS API with the same code.
+type TypesSetup struct {
+	Nodes []TypeSetup `json:"nodes,omitempty"`
+	Links []TypeSetup `json:"links,omitempty"`
+}
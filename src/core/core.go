@@ -12,6 +12,7 @@ import (
 
 	"github.com/teocci/go-hynix-3d-viewer/src/config"
 	"github.com/teocci/go-hynix-3d-viewer/src/db"
+	"github.com/teocci/go-hynix-3d-viewer/src/geocache"
 	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
 	"github.com/teocci/go-hynix-3d-viewer/src/webserver"
 )
@@ -23,12 +24,17 @@ func Start() error {
 	cfg := config.Get()
 	profile := config.ActiveProfile()
 
+	if err := db.SetMasterKey(cfg.Security.MasterKey); err != nil {
+		log.Printf("Provider credentials cannot be stored: %v", err)
+	}
+
 	dbInstance := db.GetDB()
 	if dbInstance == nil {
 		log.Fatal("Failed to initialize the database.")
 	}
 	
 	gisapi.InitVars(profile)
+	geocache.Init(cfg.Cache)
 
 	go webserver.Start(cfg)
 
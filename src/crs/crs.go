@@ -0,0 +1,103 @@
+// Package crs
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-07
+package crs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	CodeWGS84        = 4326
+	CodeWebMercator  = 3857
+	CodeUTMK         = 5179
+	CodeKoreaWest    = 5185
+	CodeKoreaCenter  = 5186
+	CodeKoreaEast    = 5187
+	CodeKoreaEastSea = 5188
+
+	codeUTMNorth = 32600
+	codeUTMSouth = 32700
+)
+
+// CRS converts between its own coordinates and WGS84 longitude and latitude in degrees.
+// Heights are not changed by any CRS. The Korean systems use GRS80, which is treated
+// as identical to WGS84 without a datum shift.
+type CRS interface {
+	Code() int
+	Name() string
+	FromLonLat(lon, lat float64) (x, y float64)
+	ToLonLat(x, y float64) (lon, lat float64)
+}
+
+// Parse accepts "EPSG:5186", "epsg:5186", "5186", "WGS84" or "WebMercator".
+func Parse(s string) (CRS, error) {
+	s = strings.TrimSpace(s)
+	switch strings.ToUpper(s) {
+	case "WGS84", "CRS84":
+		return ByCode(CodeWGS84)
+	case "WEBMERCATOR":
+		return ByCode(CodeWebMercator)
+	}
+
+	code := strings.TrimPrefix(strings.ToUpper(s), "EPSG:")
+	n, err := strconv.Atoi(code)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCRS, s)
+	}
+
+	return ByCode(n)
+}
+
+// ByCode returns the CRS with the EPSG code.
+func ByCode(code int) (CRS, error) {
+	switch {
+	case code == CodeWGS84:
+		return geographic{}, nil
+	case code == CodeWebMercator:
+		return webMercator{}, nil
+	case code > codeUTMNorth && code <= codeUTMNorth+60:
+		return utm(code, code-codeUTMNorth, false), nil
+	case code > codeUTMSouth && code <= codeUTMSouth+60:
+		return utm(code, code-codeUTMSouth, true), nil
+	}
+
+	if tm, ok := korean[code]; ok {
+		return tm, nil
+	}
+
+	return nil, fmt.Errorf("%w: EPSG:%d", ErrUnknownCRS, code)
+}
+
+// Identifier formats the code the way Parse and GeoJSON readers expect it.
+func Identifier(c CRS) string {
+	return fmt.Sprintf("EPSG:%d", c.Code())
+}
+
+// korean holds the Korea 2000 belts and UTM-K.
+var korean = map[int]transverseMercator{
+	CodeUTMK:         {code: CodeUTMK, name: "Korea 2000 / Unified CS", e: grs80, lon0: 127.5, lat0: 38, k0: 0.9996, fe: 1000000, fn: 2000000},
+	CodeKoreaWest:    {code: CodeKoreaWest, name: "Korea 2000 / West Belt 2010", e: grs80, lon0: 125, lat0: 38, k0: 1, fe: 200000, fn: 600000},
+	CodeKoreaCenter:  {code: CodeKoreaCenter, name: "Korea 2000 / Central Belt 2010", e: grs80, lon0: 127, lat0: 38, k0: 1, fe: 200000, fn: 600000},
+	CodeKoreaEast:    {code: CodeKoreaEast, name: "Korea 2000 / East Belt 2010", e: grs80, lon0: 129, lat0: 38, k0: 1, fe: 200000, fn: 600000},
+	CodeKoreaEastSea: {code: CodeKoreaEastSea, name: "Korea 2000 / East Sea Belt 2010", e: grs80, lon0: 131, lat0: 38, k0: 1, fe: 200000, fn: 600000},
+}
+
+func utm(code, zone int, south bool) transverseMercator {
+	hemisphere, fn := "N", 0.0
+	if south {
+		hemisphere, fn = "S", 10000000
+	}
+
+	return transverseMercator{
+		code: code,
+		name: fmt.Sprintf("WGS 84 / UTM zone %d%s", zone, hemisphere),
+		e:    wgs84,
+		lon0: float64(zone*6 - 183),
+		k0:   0.9996,
+		fe:   500000,
+		fn:   fn,
+	}
+}
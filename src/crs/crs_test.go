@@ -0,0 +1,75 @@
+// Package crs
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-07
+package crs
+
+import (
+	"math"
+	"testing"
+)
+
+func near(a, b, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
+
+func TestParse(t *testing.T) {
+	for input, code := range map[string]int{"EPSG:5186": 5186, "epsg:4326": 4326, "3857": 3857, "WGS84": 4326, "EPSG:32652": 32652} {
+		c, err := Parse(input)
+		if err != nil || c.Code() != code {
+			t.Errorf("Parse(%q): expected %d, got %v, %v", input, code, c, err)
+		}
+	}
+
+	if _, err := Parse("EPSG:9999"); err == nil {
+		t.Errorf("Expected an error for an unsupported code")
+	}
+}
+
+func TestKoreaCentralBeltOrigin(t *testing.T) {
+	c, _ := ByCode(CodeKoreaCenter)
+	x, y := c.FromLonLat(127, 38)
+	if !near(x, 200000, 1e-6) || !near(y, 600000, 1e-6) {
+		t.Errorf("Expected the false origin, got %f, %f", x, y)
+	}
+}
+
+func TestUTMZone(t *testing.T) {
+	c, _ := ByCode(32652)
+	// One degree east of the central meridian on the equator.
+	x, y := c.FromLonLat(130, 0)
+	if !near(x, 611280.65, 0.01) || !near(y, 0, 1e-6) {
+		t.Errorf("Expected about 611280.65, 0, got %f, %f", x, y)
+	}
+}
+
+func TestWebMercator(t *testing.T) {
+	c, _ := ByCode(CodeWebMercator)
+	x, _ := c.FromLonLat(180, 0)
+	if !near(x, 20037508.34, 0.01) {
+		t.Errorf("Expected 20037508.34, got %f", x)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, code := range []int{CodeUTMK, CodeKoreaWest, CodeKoreaCenter, CodeKoreaEast, CodeKoreaEastSea, 32652, CodeWebMercator} {
+		c, _ := ByCode(code)
+		x, y := c.FromLonLat(127.3, 36.35)
+		lon, lat := c.ToLonLat(x, y)
+		// The series loses accuracy away from the central meridian: 1e-7 degrees is about a centimetre.
+		if !near(lon, 127.3, 1e-7) || !near(lat, 36.35, 1e-7) {
+			t.Errorf("EPSG:%d: expected 127.3, 36.35, got %f, %f", code, lon, lat)
+		}
+	}
+}
+
+func TestTransformerOrigin(t *testing.T) {
+	src, _ := ByCode(CodeKoreaCenter)
+	dst, _ := ByCode(CodeKoreaEast)
+	tr := NewTransformer(src, dst).WithOrigin([]float64{0, 0, 10})
+
+	p := tr.Position([]float64{200000, 600000, 15})
+	lon, lat := dst.ToLonLat(p[0], p[1])
+	if !near(lon, 127, 1e-8) || !near(lat, 38, 1e-8) || p[2] != 5 {
+		t.Errorf("Expected 127, 38 and a height of 5, got %f, %f, %f", lon, lat, p[2])
+	}
+}
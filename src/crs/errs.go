@@ -0,0 +1,10 @@
+// Package crs
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-07
+package crs
+
+import "errors"
+
+var (
+	ErrUnknownCRS = errors.New("unsupported coordinate reference system")
+)
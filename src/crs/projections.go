@@ -0,0 +1,122 @@
+// Package crs
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-07
+package crs
+
+import "math"
+
+const (
+	degree       = math.Pi / 180
+	sphereRadius = 6378137.0
+)
+
+type ellipsoid struct {
+	a float64 // semi-major axis
+	f float64 // flattening
+}
+
+var (
+	wgs84 = ellipsoid{a: 6378137, f: 1 / 298.257223563}
+	grs80 = ellipsoid{a: 6378137, f: 1 / 298.257222101}
+)
+
+// geographic is WGS84 longitude and latitude in degrees.
+type geographic struct{}
+
+func (geographic) Code() int    { return CodeWGS84 }
+func (geographic) Name() string { return "WGS 84" }
+
+func (geographic) FromLonLat(lon, lat float64) (float64, float64) { return lon, lat }
+func (geographic) ToLonLat(x, y float64) (float64, float64)       { return x, y }
+
+// webMercator is the spherical Mercator used by web maps.
+type webMercator struct{}
+
+func (webMercator) Code() int    { return CodeWebMercator }
+func (webMercator) Name() string { return "WGS 84 / Pseudo-Mercator" }
+
+func (webMercator) FromLonLat(lon, lat float64) (float64, float64) {
+	return sphereRadius * lon * degree, sphereRadius * math.Log(math.Tan(math.Pi/4+lat*degree/2))
+}
+
+func (webMercator) ToLonLat(x, y float64) (float64, float64) {
+	return x / sphereRadius / degree, (2*math.Atan(math.Exp(y/sphereRadius)) - math.Pi/2) / degree
+}
+
+// transverseMercator implements the ellipsoidal series of Snyder, Map Projections:
+// A Working Manual (USGS PP 1395), pp. 61-64.
+type transverseMercator struct {
+	code       int
+	name       string
+	e          ellipsoid
+	lon0, lat0 float64 // degrees
+	k0         float64
+	fe, fn     float64
+}
+
+func (tm transverseMercator) Code() int    { return tm.code }
+func (tm transverseMercator) Name() string { return tm.name }
+
+func (tm transverseMercator) FromLonLat(lon, lat float64) (float64, float64) {
+	a, e2 := tm.e.a, tm.eccentricity2()
+	ep2 := e2 / (1 - e2)
+	phi := lat * degree
+	sin, cos, tan := math.Sin(phi), math.Cos(phi), math.Tan(phi)
+
+	n := a / math.Sqrt(1-e2*sin*sin)
+	t := tan * tan
+	c := ep2 * cos * cos
+	A := (lon - tm.lon0) * degree * cos
+	m := tm.meridian(phi)
+	m0 := tm.meridian(tm.lat0 * degree)
+
+	x := tm.k0 * n * (A + (1-t+c)*math.Pow(A, 3)/6 + (5-18*t+t*t+72*c-58*ep2)*math.Pow(A, 5)/120)
+	y := tm.k0 * (m - m0 + n*tan*(A*A/2+(5-t+9*c+4*c*c)*math.Pow(A, 4)/24+(61-58*t+t*t+600*c-330*ep2)*math.Pow(A, 6)/720))
+
+	return x + tm.fe, y + tm.fn
+}
+
+func (tm transverseMercator) ToLonLat(x, y float64) (float64, float64) {
+	a, e2 := tm.e.a, tm.eccentricity2()
+	ep2 := e2 / (1 - e2)
+	x -= tm.fe
+	y -= tm.fn
+
+	m := tm.meridian(tm.lat0*degree) + y/tm.k0
+	mu := m / (a * (1 - e2/4 - 3*e2*e2/64 - 5*e2*e2*e2/256))
+	e1 := (1 - math.Sqrt(1-e2)) / (1 + math.Sqrt(1-e2))
+	phi1 := mu + (3*e1/2-27*math.Pow(e1, 3)/32)*math.Sin(2*mu) +
+		(21*e1*e1/16-55*math.Pow(e1, 4)/32)*math.Sin(4*mu) +
+		(151*math.Pow(e1, 3)/96)*math.Sin(6*mu) +
+		(1097*math.Pow(e1, 4)/512)*math.Sin(8*mu)
+
+	sin, cos, tan := math.Sin(phi1), math.Cos(phi1), math.Tan(phi1)
+	c1 := ep2 * cos * cos
+	t1 := tan * tan
+	n1 := a / math.Sqrt(1-e2*sin*sin)
+	r1 := a * (1 - e2) / math.Pow(1-e2*sin*sin, 1.5)
+	d := x / (n1 * tm.k0)
+
+	phi := phi1 - (n1*tan/r1)*(d*d/2-
+		(5+3*t1+10*c1-4*c1*c1-9*ep2)*math.Pow(d, 4)/24+
+		(61+90*t1+298*c1+45*t1*t1-252*ep2-3*c1*c1)*math.Pow(d, 6)/720)
+	lambda := (d - (1+2*t1+c1)*math.Pow(d, 3)/6 +
+		(5-2*c1+28*t1-3*c1*c1+8*ep2+24*t1*t1)*math.Pow(d, 5)/120) / cos
+
+	return tm.lon0 + lambda/degree, phi / degree
+}
+
+func (tm transverseMercator) eccentricity2() float64 {
+	return tm.e.f * (2 - tm.e.f)
+}
+
+// meridian returns the distance along the meridian from the equator to latitude phi.
+func (tm transverseMercator) meridian(phi float64) float64 {
+	e2 := tm.eccentricity2()
+	e4, e6 := e2*e2, e2*e2*e2
+
+	return tm.e.a * ((1-e2/4-3*e4/64-5*e6/256)*phi -
+		(3*e2/8+3*e4/32+45*e6/1024)*math.Sin(2*phi) +
+		(15*e4/256+45*e6/1024)*math.Sin(4*phi) -
+		(35*e6/3072)*math.Sin(6*phi))
+}
@@ -0,0 +1,120 @@
+// Package crs
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-07
+package crs
+
+import (
+	"math"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+)
+
+// Transformer reprojects positions from one CRS to another and can then subtract a local
+// origin, so large projected coordinates fit in float32 buffers without losing precision.
+// A nil source or target keeps the coordinates as they are.
+type Transformer struct {
+	src, dst CRS
+	origin   []float64
+}
+
+// NewTransformer creates a transformer between two systems.
+func NewTransformer(src, dst CRS) *Transformer {
+	return &Transformer{src: src, dst: dst}
+}
+
+// WithOrigin subtracts origin from every transformed position.
+func (t *Transformer) WithOrigin(origin []float64) *Transformer {
+	t.origin = origin
+	return t
+}
+
+// Origin returns the local origin, or nil when positions are absolute.
+func (t *Transformer) Origin() []float64 {
+	return t.origin
+}
+
+// Reprojects reports whether the source and target systems differ.
+func (t *Transformer) Reprojects() bool {
+	return t.src != nil && t.dst != nil && t.src.Code() != t.dst.Code()
+}
+
+// Position returns a transformed copy of an [x, y, z...] position.
+func (t *Transformer) Position(p []float64) []float64 {
+	out := make([]float64, len(p))
+	copy(out, p)
+	if len(p) < 2 {
+		return out
+	}
+
+	if t.Reprojects() {
+		lon, lat := t.src.ToLonLat(p[0], p[1])
+		out[0], out[1] = t.dst.FromLonLat(lon, lat)
+	}
+	for i := 0; i < len(out) && i < len(t.origin); i++ {
+		out[i] -= t.origin[i]
+	}
+
+	return out
+}
+
+// Nodes returns a copy of the nodes with transformed positions.
+func (t *Transformer) Nodes(nodes gisapi.NodesData) gisapi.NodesData {
+	out := make(gisapi.NodesData, len(nodes))
+	for i, n := range nodes {
+		n.Geometry = t.Position(n.Geometry)
+		out[i] = n
+	}
+
+	return out
+}
+
+// Links returns a copy of the links with transformed polylines.
+func (t *Transformer) Links(links gisapi.LinksData) gisapi.LinksData {
+	out := make(gisapi.LinksData, len(links))
+	for i, l := range links {
+		l.Geometry = t.Polyline(l.Geometry)
+		out[i] = l
+	}
+
+	return out
+}
+
+// Polyline returns a transformed copy of a list of positions.
+func (t *Transformer) Polyline(points [][]float64) [][]float64 {
+	out := make([][]float64, len(points))
+	for i, p := range points {
+		out[i] = t.Position(p)
+	}
+
+	return out
+}
+
+// LocalOrigin returns the center of the bounds of the nodes and links, rounded to whole units.
+// Callers requesting nodes and links separately should reuse the first origin they get.
+func LocalOrigin(nodes gisapi.NodesData, links gisapi.LinksData) []float64 {
+	inf := math.Inf(1)
+	lo, hi := []float64{inf, inf, inf}, []float64{-inf, -inf, -inf}
+	extend := func(p []float64) {
+		for i := 0; i < 3 && i < len(p); i++ {
+			lo[i] = math.Min(lo[i], p[i])
+			hi[i] = math.Max(hi[i], p[i])
+		}
+	}
+	for _, n := range nodes {
+		extend(n.Geometry)
+	}
+	for _, l := range links {
+		for _, p := range l.Geometry {
+			extend(p)
+		}
+	}
+
+	origin := make([]float64, 3)
+	for i := range origin {
+		if lo[i] <= hi[i] {
+			origin[i] = math.Round((lo[i] + hi[i]) / 2)
+		}
+	}
+
+	return origin
+}
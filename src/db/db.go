@@ -17,6 +17,11 @@ var (
 	once       sync.Once
 )
 
+// models lists the tables of the app.
+var models = []any{&User{}, &Provider{}, &UserProvider{}, &RefreshToken{}, &ProviderResource{},
+	&GISCollection{}, &GISCollectionPoint{}, &GISCollectionLine{}, &GISCollectionPolyline{}, &GISCollectionPolygon{},
+	&GISCollectionRevision{}}
+
 // GetDB returns a singleton instance of the database.
 func GetDB() *gorm.DB {
 	once.Do(func() {
@@ -26,12 +31,26 @@ func GetDB() *gorm.DB {
 			log.Fatal("Failed to connect to database:", err)
 		}
 
+		if err = migrateLegacyUserProviders(dbInstance); err != nil {
+			log.Fatal("Failed to migrate legacy user providers:", err)
+		}
+
 		// Auto-migrate models
-		err = dbInstance.AutoMigrate(&User{}, &Provider{}, &UserProvider{})
+		err = dbInstance.AutoMigrate(models...)
 		if err != nil {
 			log.Fatal("Failed to migrate database:", err)
 		}
 
+		if HasMasterKey() {
+			if err = encryptLegacyProviderCredentials(dbInstance); err != nil {
+				log.Fatal("Failed to encrypt provider credentials:", err)
+			}
+		}
+
+		if err = backfillGISCollectionRevisions(dbInstance); err != nil {
+			log.Fatal("Failed to backfill collection revisions:", err)
+		}
+
 		// Load initial users if none exist
 		seedUsers(dbInstance)
 	})
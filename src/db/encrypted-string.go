@@ -0,0 +1,145 @@
+// Package db
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-21
+package db
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const encryptedPrefix = "enc:v1:"
+
+var (
+	masterAEAD  cipher.AEAD
+	masterMutex sync.RWMutex
+)
+
+// EncryptedString is a string column encrypted with AES-GCM using the master key.
+// Values written before encryption was enabled are read back as plain text
+// and encrypted the next time the row is saved.
+type EncryptedString string
+
+// SetMasterKey configures the key used to encrypt EncryptedString columns.
+// The key is derived from the SHA-256 digest of the given secret.
+func SetMasterKey(secret string) error {
+	if secret == "" {
+		return ErrMasterKeyMissing
+	}
+
+	key := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+
+	masterMutex.Lock()
+	defer masterMutex.Unlock()
+
+	masterAEAD = aead
+
+	return nil
+}
+
+// HasMasterKey reports whether encrypted columns can be written.
+func HasMasterKey() bool {
+	masterMutex.RLock()
+	defer masterMutex.RUnlock()
+
+	return masterAEAD != nil
+}
+
+// Value encrypts the string before it is stored.
+func (s EncryptedString) Value() (driver.Value, error) {
+	if s == "" {
+		return "", nil
+	}
+
+	masterMutex.RLock()
+	aead := masterAEAD
+	masterMutex.RUnlock()
+	if aead == nil {
+		return nil, ErrMasterKeyMissing
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(s), nil)
+
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Scan decrypts the stored value.
+func (s *EncryptedString) Scan(value any) error {
+	var raw string
+	switch v := value.(type) {
+	case nil:
+		*s = ""
+		return nil
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedColumnType, value)
+	}
+
+	if !strings.HasPrefix(raw, encryptedPrefix) {
+		// Legacy plain-text value
+		*s = EncryptedString(raw)
+		return nil
+	}
+
+	masterMutex.RLock()
+	aead := masterAEAD
+	masterMutex.RUnlock()
+	if aead == nil {
+		return ErrMasterKeyMissing
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(raw, encryptedPrefix))
+	if err != nil {
+		return ErrDecryptionFailed
+	}
+	if len(sealed) < aead.NonceSize() {
+		return ErrDecryptionFailed
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return ErrDecryptionFailed
+	}
+
+	*s = EncryptedString(plain)
+
+	return nil
+}
+
+// Plain returns the decrypted value.
+func (s EncryptedString) Plain() string {
+	return string(s)
+}
+
+// String redacts the value so secrets never end up in logs.
+func (s EncryptedString) String() string {
+	if s == "" {
+		return ""
+	}
+	return "[redacted]"
+}
@@ -0,0 +1,71 @@
+// Package db
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-21
+package db
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEncryptedStringRoundTrip(t *testing.T) {
+	if err := SetMasterKey("test-master-key"); err != nil {
+		t.Fatalf("SetMasterKey failed: %v", err)
+	}
+
+	value, err := EncryptedString("p@ssw0rd").Value()
+	if err != nil {
+		t.Fatalf("Value failed: %v", err)
+	}
+
+	stored, ok := value.(string)
+	if !ok || !strings.HasPrefix(stored, encryptedPrefix) {
+		t.Fatalf("Expected an encrypted value, got %v", value)
+	}
+	if strings.Contains(stored, "p@ssw0rd") {
+		t.Fatalf("Stored value leaks the plain text: %s", stored)
+	}
+
+	var decoded EncryptedString
+	if err := decoded.Scan(stored); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if decoded.Plain() != "p@ssw0rd" {
+		t.Errorf("Expected 'p@ssw0rd', got '%s'", decoded.Plain())
+	}
+}
+
+func TestEncryptedStringReadsLegacyPlainText(t *testing.T) {
+	var decoded EncryptedString
+	if err := decoded.Scan([]byte("legacy")); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if decoded.Plain() != "legacy" {
+		t.Errorf("Expected 'legacy', got '%s'", decoded.Plain())
+	}
+}
+
+func TestEncryptedStringWrongKey(t *testing.T) {
+	if err := SetMasterKey("key-one"); err != nil {
+		t.Fatalf("SetMasterKey failed: %v", err)
+	}
+	value, err := EncryptedString("secret").Value()
+	if err != nil {
+		t.Fatalf("Value failed: %v", err)
+	}
+
+	if err := SetMasterKey("key-two"); err != nil {
+		t.Fatalf("SetMasterKey failed: %v", err)
+	}
+	var decoded EncryptedString
+	if err := decoded.Scan(value); !errors.Is(err, ErrDecryptionFailed) {
+		t.Errorf("Expected ErrDecryptionFailed, got %v", err)
+	}
+}
+
+func TestEncryptedStringRedactsOnPrint(t *testing.T) {
+	if s := EncryptedString("secret").String(); s == "secret" {
+		t.Errorf("String() must not reveal the value")
+	}
+}
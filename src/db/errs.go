@@ -10,4 +10,11 @@ var (
 	ErrExists                = errors.New("already exists")
 	ErrNoRows                = errors.New("no rows")
 	ErrProviderAlreadyLinked = errors.New("user is already linked to this provider")
+	ErrUsernameTaken         = errors.New("username is already taken")
+	ErrInvalidRole           = errors.New("invalid role")
+	ErrLastAdmin             = errors.New("cannot remove the last active admin")
+	ErrMasterKeyMissing      = errors.New("master key is not configured")
+	ErrDecryptionFailed      = errors.New("failed to decrypt value")
+	ErrUnsupportedColumnType = errors.New("unsupported column type")
+	ErrRevisionImmutable     = errors.New("revisions cannot be modified")
 )
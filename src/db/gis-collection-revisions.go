@@ -0,0 +1,35 @@
+// Package db
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-26
+package db
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisdata"
+)
+
+// GISCollectionRevision is an immutable snapshot of a collection taken on every write.
+// Numbers start at 1 and grow by one per collection.
+type GISCollectionRevision struct {
+	gorm.Model
+	UUID           string          `gorm:"type:char(36);unique;not null"`
+	CollectionUUID string          `gorm:"type:char(36);not null;uniqueIndex:idx_collection_revision"`
+	Number         int             `gorm:"not null;uniqueIndex:idx_collection_revision"`
+	Name           string          `gorm:"type:varchar(255);not null"`
+	AuthorUUID     string          `gorm:"type:char(36)"`
+	Message        string          `gorm:"type:varchar(255)"`
+	Data           gisdata.GISData `gorm:"serializer:json"`
+}
+
+// BeforeCreate hook to generate UUID
+func (r *GISCollectionRevision) BeforeCreate(tx *gorm.DB) (err error) {
+	r.UUID = uuid.New().String()
+	return
+}
+
+// BeforeUpdate keeps revisions immutable.
+func (r *GISCollectionRevision) BeforeUpdate(tx *gorm.DB) (err error) {
+	return ErrRevisionImmutable
+}
@@ -0,0 +1,129 @@
+// Package db
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-24
+package db
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisdata"
+)
+
+// GISCollection is a named set of points, lines, polylines and polygons owned by a provider.
+type GISCollection struct {
+	gorm.Model
+	UUID         string `gorm:"type:char(36);unique;not null"`
+	Name         string `gorm:"type:varchar(255);not null"`
+	ProviderUUID string `gorm:"type:char(36);not null;index"`
+
+	Points    []GISCollectionPoint    `gorm:"foreignKey:CollectionUUID;references:UUID;constraint:OnDelete:CASCADE"`
+	Lines     []GISCollectionLine     `gorm:"foreignKey:CollectionUUID;references:UUID;constraint:OnDelete:CASCADE"`
+	Polylines []GISCollectionPolyline `gorm:"foreignKey:CollectionUUID;references:UUID;constraint:OnDelete:CASCADE"`
+	Polygons  []GISCollectionPolygon  `gorm:"foreignKey:CollectionUUID;references:UUID;constraint:OnDelete:CASCADE"`
+}
+
+// GISCollectionPoint is a point of a collection. ElementID is the GISPoint.Id.
+type GISCollectionPoint struct {
+	ID             uint      `gorm:"primaryKey"`
+	CollectionUUID string    `gorm:"type:char(36);not null;index"`
+	Seq            int       `gorm:"not null"`
+	ElementID      string    `gorm:"type:varchar(255);not null"`
+	Coordinates    []float64 `gorm:"serializer:json"`
+}
+
+// GISCollectionLine is a line of a collection referencing two points by ID.
+type GISCollectionLine struct {
+	ID             uint   `gorm:"primaryKey"`
+	CollectionUUID string `gorm:"type:char(36);not null;index"`
+	Seq            int    `gorm:"not null"`
+	ElementID      string `gorm:"type:varchar(255);not null"`
+	Start          string `gorm:"type:varchar(255);not null"`
+	End            string `gorm:"type:varchar(255);not null"`
+}
+
+// GISCollectionPolyline is a polyline of a collection referencing its points by ID.
+type GISCollectionPolyline struct {
+	ID             uint     `gorm:"primaryKey"`
+	CollectionUUID string   `gorm:"type:char(36);not null;index"`
+	Seq            int      `gorm:"not null"`
+	ElementID      string   `gorm:"type:varchar(255);not null"`
+	Nodes          []string `gorm:"serializer:json"`
+}
+
+// GISCollectionPolygon is a polygon of a collection defined by its vertices.
+type GISCollectionPolygon struct {
+	ID             uint        `gorm:"primaryKey"`
+	CollectionUUID string      `gorm:"type:char(36);not null;index"`
+	Seq            int         `gorm:"not null"`
+	ElementID      string      `gorm:"type:varchar(255);not null"`
+	Vertices       [][]float64 `gorm:"serializer:json"`
+}
+
+// BeforeCreate hook to generate UUID. Imported collections keep their original UUID.
+func (c *GISCollection) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.UUID == "" {
+		c.UUID = uuid.New().String()
+	}
+	return
+}
+
+// GISData converts the stored elements into the API representation, keeping their order.
+func (c *GISCollection) GISData() gisdata.GISData {
+	data := gisdata.GISData{
+		Points:    make([]gisdata.GISPoint, len(c.Points)),
+		Lines:     make([]gisdata.GISLine, len(c.Lines)),
+		Polylines: make([]gisdata.GISPolyline, len(c.Polylines)),
+		Polygons:  make([]gisdata.GISPolygon, len(c.Polygons)),
+	}
+
+	for i, p := range c.Points {
+		data.Points[i] = gisdata.GISPoint{Id: p.ElementID, Coordinates: p.Coordinates}
+	}
+	for i, l := range c.Lines {
+		data.Lines[i] = gisdata.GISLine{Id: l.ElementID, Start: l.Start, End: l.End}
+	}
+	for i, pl := range c.Polylines {
+		data.Polylines[i] = gisdata.GISPolyline{Id: pl.ElementID, Nodes: pl.Nodes}
+	}
+	for i, pg := range c.Polygons {
+		data.Polygons[i] = gisdata.GISPolygon{Id: pg.ElementID, Vertices: pg.Vertices}
+	}
+
+	return data
+}
+
+// SetGISData replaces the in-memory elements with the given data.
+func (c *GISCollection) SetGISData(data gisdata.GISData) {
+	c.Points = make([]GISCollectionPoint, len(data.Points))
+	for i, p := range data.Points {
+		c.Points[i] = GISCollectionPoint{CollectionUUID: c.UUID, Seq: i, ElementID: p.Id, Coordinates: p.Coordinates}
+	}
+
+	c.Lines = make([]GISCollectionLine, len(data.Lines))
+	for i, l := range data.Lines {
+		c.Lines[i] = GISCollectionLine{CollectionUUID: c.UUID, Seq: i, ElementID: l.Id, Start: l.Start, End: l.End}
+	}
+
+	c.Polylines = make([]GISCollectionPolyline, len(data.Polylines))
+	for i, pl := range data.Polylines {
+		c.Polylines[i] = GISCollectionPolyline{CollectionUUID: c.UUID, Seq: i, ElementID: pl.Id, Nodes: pl.Nodes}
+	}
+
+	c.Polygons = make([]GISCollectionPolygon, len(data.Polygons))
+	for i, pg := range data.Polygons {
+		c.Polygons[i] = GISCollectionPolygon{CollectionUUID: c.UUID, Seq: i, ElementID: pg.Id, Vertices: pg.Vertices}
+	}
+}
+
+// AsGISCollection converts the model into the API representation.
+// The GIS data is only included when the elements were loaded.
+func (c *GISCollection) AsGISCollection(withData bool) gisdata.GISCollection {
+	col := gisdata.GISCollection{Name: c.Name, UUID: c.UUID, Provider: c.ProviderUUID}
+	if withData {
+		data := c.GISData()
+		col.GIS = &data
+	}
+
+	return col
+}
@@ -0,0 +1,97 @@
+// Package db
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-19
+package db
+
+import (
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// migrateLegacyUserProviders rebuilds the user_providers table created when User and Provider
+// were embedded in UserProvider, which flattened their unique columns into the link table.
+// Existing links are kept.
+func migrateLegacyUserProviders(db *gorm.DB) error {
+	m := db.Migrator()
+	if !m.HasTable(&UserProvider{}) || !m.HasColumn(&UserProvider{}, "username") {
+		return nil
+	}
+
+	type legacyLink struct {
+		UserUUID     string
+		ProviderUUID string
+	}
+
+	var links []legacyLink
+	if err := db.Table("user_providers").
+		Select("user_uuid, provider_uuid").
+		Where("deleted_at IS NULL").
+		Scan(&links).Error; err != nil {
+		return err
+	}
+
+	if err := m.DropTable(&UserProvider{}); err != nil {
+		return err
+	}
+	if err := m.AutoMigrate(&UserProvider{}); err != nil {
+		return err
+	}
+
+	for _, l := range links {
+		link := UserProvider{UserUUID: l.UserUUID, ProviderUUID: l.ProviderUUID, Role: RoleViewer}
+		if err := db.Omit("User", "Provider").Create(&link).Error; err != nil {
+			return err
+		}
+	}
+
+	log.Printf("Migrated %d legacy user-provider links", len(links))
+
+	return nil
+}
+
+// encryptLegacyProviderCredentials re-saves providers whose credentials were stored in plain text.
+func encryptLegacyProviderCredentials(db *gorm.DB) error {
+	var providers []Provider
+	err := db.Where("(db_user <> '' AND db_user NOT LIKE ?) OR (db_password <> '' AND db_password NOT LIKE ?)",
+		encryptedPrefix+"%", encryptedPrefix+"%").
+		Find(&providers).Error
+	if err != nil {
+		return err
+	}
+
+	for _, p := range providers {
+		if err := db.Model(&p).Select("DBUser", "DBPassword").Updates(&p).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(providers) > 0 {
+		log.Printf("Encrypted credentials of %d providers", len(providers))
+	}
+
+	return nil
+}
+
+// backfillGISCollectionRevisions creates the first revision of collections stored before versioning.
+func backfillGISCollectionRevisions(db *gorm.DB) error {
+	var collections []GISCollection
+	err := preloadGISElements(db).
+		Where("uuid NOT IN (?)", db.Model(&GISCollectionRevision{}).Select("collection_uuid")).
+		Find(&collections).Error
+	if err != nil {
+		return err
+	}
+
+	for _, c := range collections {
+		if _, err := createGISCollectionRevision(db, &c, "", "Initial revision"); err != nil {
+			return err
+		}
+	}
+
+	if len(collections) > 0 {
+		log.Printf("Created initial revisions for %d collections", len(collections))
+	}
+
+	return nil
+}
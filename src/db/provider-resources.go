@@ -0,0 +1,22 @@
+// Package db
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-19
+package db
+
+import "gorm.io/gorm"
+
+// ResourceKind identifies the kind of resource a provider owns.
+type ResourceKind string
+
+const (
+	ResourceNetwork    ResourceKind = "network"
+	ResourceCollection ResourceKind = "collection"
+)
+
+// ProviderResource registers a network or collection UUID under the provider that owns it.
+type ProviderResource struct {
+	gorm.Model
+	ProviderUUID string       `gorm:"type:char(36);not null;index;uniqueIndex:idx_provider_resource"`
+	Kind         ResourceKind `gorm:"type:varchar(16);not null;uniqueIndex:idx_provider_resource"`
+	ResourceUUID string       `gorm:"type:char(36);not null;index;uniqueIndex:idx_provider_resource"`
+}
@@ -8,13 +8,15 @@ import (
 	"gorm.io/gorm"
 )
 
+// Provider is a GIS database connection. Its credentials are encrypted at rest.
 type Provider struct {
 	gorm.Model
-	UUID       string `gorm:"type:char(36);primaryKey;unique;not null"`
-	Host       string `gorm:"type:varchar(255);not null"`
-	Port       int    `gorm:"type:integer;not null"`
-	DBUser     string `gorm:"type:varchar(255);not null"`
-	DBPassword string `gorm:"type:varchar(255);not null"`
+	UUID       string          `gorm:"type:char(36);primaryKey;unique;not null"`
+	Name       string          `gorm:"type:varchar(255);not null;default:''"`
+	Host       string          `gorm:"type:varchar(255);not null"`
+	Port       int             `gorm:"type:integer;not null"`
+	DBUser     EncryptedString `gorm:"type:varchar(255);not null"`
+	DBPassword EncryptedString `gorm:"type:varchar(255);not null"`
 }
 
 // BeforeCreate hook to generate UUID
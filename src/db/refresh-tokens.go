@@ -0,0 +1,33 @@
+// Package db
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-18
+package db
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RefreshToken represents a long-lived token used to issue new access tokens.
+// Only the SHA-256 hash of the token is stored.
+type RefreshToken struct {
+	gorm.Model
+	UUID      string     `gorm:"type:char(36);unique;not null"`
+	UserUUID  string     `gorm:"type:char(36);not null;index"`
+	TokenHash string     `gorm:"type:char(64);unique;not null"`
+	ExpiresAt time.Time  `gorm:"not null"`
+	RevokedAt *time.Time `gorm:"index"`
+}
+
+// BeforeCreate hook to generate UUID
+func (rt *RefreshToken) BeforeCreate(tx *gorm.DB) (err error) {
+	rt.UUID = uuid.New().String()
+	return
+}
+
+// IsActive reports whether the token is neither revoked nor expired.
+func (rt *RefreshToken) IsActive() bool {
+	return rt.RevokedAt == nil && time.Now().Before(rt.ExpiresAt)
+}
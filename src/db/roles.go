@@ -0,0 +1,50 @@
+// Package db
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-19
+package db
+
+// Role is the access level of a user, globally or over a single provider.
+type Role string
+
+const (
+	RoleAdmin  Role = "admin"
+	RoleEditor Role = "editor"
+	RoleViewer Role = "viewer"
+)
+
+// ParseRole converts a string into a known Role.
+func ParseRole(role string) (Role, bool) {
+	switch Role(role) {
+	case RoleAdmin, RoleEditor, RoleViewer:
+		return Role(role), true
+	default:
+		return "", false
+	}
+}
+
+// Level returns the rank of the role; higher ranks include the lower ones.
+func (r Role) Level() int {
+	switch r {
+	case RoleAdmin:
+		return 3
+	case RoleEditor:
+		return 2
+	case RoleViewer:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Allows reports whether the role satisfies the required one.
+func (r Role) Allows(required Role) bool {
+	return r.Level() >= required.Level()
+}
+
+// Min returns the lower of two roles.
+func (r Role) Min(other Role) Role {
+	if other.Level() < r.Level() {
+		return other
+	}
+	return r
+}
@@ -18,6 +18,7 @@ type UserJSON struct {
 	Name     string `json:"name"`
 	Username string `json:"username"`
 	Password string `json:"password"` // Plain-text in JSON, but will be hashed before storing
+	Role     string `json:"role,omitempty"`
 }
 
 // seedUsers loads users from `users.json` if none exist in the database.
@@ -32,6 +33,10 @@ func seedUsers(db *gorm.DB) {
 	// Read users from `users.json`
 	file, err := os.ReadFile("users.json")
 	if err != nil {
+		if os.IsNotExist(err) {
+			log.Println("No users.json found. Create users with `hynix3dv user add`.")
+			return
+		}
 		log.Fatal("Failed to read users.json:", err)
 	}
 
@@ -48,11 +53,17 @@ func seedUsers(db *gorm.DB) {
 			log.Fatal("Failed to hash password for user:", u.Username)
 		}
 
+		role, ok := ParseRole(u.Role)
+		if !ok {
+			role = RoleViewer
+		}
+
 		user := User{
 			UUID:         uuid.New().String(),
 			Name:         u.Name,
 			Username:     u.Username,
 			PasswordHash: string(hashedPassword),
+			Role:         role,
 		}
 
 		if err := db.Create(&user).Error; err != nil {
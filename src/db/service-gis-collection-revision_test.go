@@ -0,0 +1,43 @@
+// Package db
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-26
+package db
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisdata"
+)
+
+func TestConcurrentRevisions(t *testing.T) {
+	useTestDB(t)
+
+	collection, err := CreateGISCollection("", "test", "provider-1", "", gisdata.GISData{})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	const writers = 8
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("name-%d", i)
+			if _, err := UpdateGISCollection(collection.UUID, GISCollectionUpdate{Name: &name}); err != nil {
+				t.Errorf("Update %d failed: %v", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	revisions, err := ListGISCollectionRevisions(collection.UUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revisions) != writers+1 || revisions[0].Number != writers+1 {
+		t.Errorf("Expected revisions 1 to %d, got %d revisions", writers+1, len(revisions))
+	}
+}
@@ -0,0 +1,266 @@
+// Package db
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-24
+package db
+
+import (
+	"errors"
+	"log"
+
+	"gorm.io/gorm"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisdata"
+	"github.com/teocci/go-hynix-3d-viewer/src/validator"
+)
+
+// CreateGISCollection stores a new collection with its elements and records its first revision.
+// An empty collectionUUID generates a new one. Invalid data is rejected with a *validator.IssuesError.
+func CreateGISCollection(collectionUUID, name, providerUUID, authorUUID string, data gisdata.GISData) (GISCollection, error) {
+	if err := validator.Check(data); err != nil {
+		return GISCollection{}, err
+	}
+
+	db := GetDB()
+
+	if collectionUUID != "" {
+		var count int64
+		if err := db.Model(&GISCollection{}).Where("uuid = ?", collectionUUID).Count(&count).Error; err != nil {
+			return GISCollection{}, err
+		}
+		if count > 0 {
+			return GISCollection{}, ErrExists
+		}
+	}
+
+	collection := GISCollection{
+		UUID:         collectionUUID,
+		Name:         name,
+		ProviderUUID: providerUUID,
+	}
+	collection.SetGISData(data)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&collection).Error; err != nil {
+			return err
+		}
+
+		_, err := createGISCollectionRevision(tx, &collection, authorUUID, "Created")
+		return err
+	})
+	if err != nil {
+		return GISCollection{}, err
+	}
+
+	log.Println("GIS collection created:", collection.UUID)
+	return collection, nil
+}
+
+// GetGISCollection fetches a collection by UUID, optionally with its elements.
+func GetGISCollection(collectionUUID string, withData bool) (GISCollection, error) {
+	db := GetDB()
+	if withData {
+		db = preloadGISElements(db)
+	}
+
+	var collection GISCollection
+	if err := db.Where("uuid = ?", collectionUUID).First(&collection).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return GISCollection{}, ErrNotFound
+		}
+		return GISCollection{}, err
+	}
+
+	return collection, nil
+}
+
+// GetGISCollectionsByUUIDs fetches several collections with their elements.
+func GetGISCollectionsByUUIDs(collectionUUIDs []string) ([]GISCollection, error) {
+	db := preloadGISElements(GetDB())
+
+	var collections []GISCollection
+	if err := db.Where("uuid IN ?", collectionUUIDs).Order("name").Find(&collections).Error; err != nil {
+		return nil, err
+	}
+
+	return collections, nil
+}
+
+// ListGISCollections returns the collections owned by the given providers without their elements.
+// A nil slice lists every collection.
+func ListGISCollections(providerUUIDs []string) ([]GISCollection, error) {
+	db := GetDB()
+	if providerUUIDs != nil {
+		db = db.Where("provider_uuid IN ?", providerUUIDs)
+	}
+
+	var collections []GISCollection
+	if err := db.Order("name").Find(&collections).Error; err != nil {
+		return nil, err
+	}
+
+	return collections, nil
+}
+
+// GISCollectionUpdate lists the collection fields to change. Nil fields are left unchanged.
+// AuthorUUID and Message are recorded in the resulting revision.
+type GISCollectionUpdate struct {
+	Name       *string
+	GIS        *gisdata.GISData
+	AuthorUUID string
+	Message    string
+}
+
+// UpdateGISCollection renames a collection and/or replaces all of its elements,
+// recording the result as a new revision. Invalid data is rejected with a *validator.IssuesError.
+func UpdateGISCollection(collectionUUID string, update GISCollectionUpdate) (GISCollection, error) {
+	if update.GIS != nil {
+		if err := validator.Check(*update.GIS); err != nil {
+			return GISCollection{}, err
+		}
+	}
+
+	collection, err := GetGISCollection(collectionUUID, true)
+	if err != nil {
+		return GISCollection{}, err
+	}
+
+	db := GetDB()
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if update.Name != nil {
+			collection.Name = *update.Name
+			if err := tx.Model(&collection).Update("name", collection.Name).Error; err != nil {
+				return err
+			}
+		}
+
+		if update.GIS != nil {
+			if err := deleteGISElements(tx, collectionUUID); err != nil {
+				return err
+			}
+
+			collection.SetGISData(*update.GIS)
+
+			if err := insertGISElements(tx, &collection); err != nil {
+				return err
+			}
+		}
+
+		message := update.Message
+		if message == "" {
+			message = "Updated"
+		}
+		_, err := createGISCollectionRevision(tx, &collection, update.AuthorUUID, message)
+		return err
+	})
+	if err != nil {
+		return GISCollection{}, err
+	}
+
+	log.Println("GIS collection updated:", collection.UUID)
+	return collection, nil
+}
+
+// DeleteGISCollection permanently removes a collection, its elements and its revisions.
+func DeleteGISCollection(collectionUUID string) error {
+	if _, err := GetGISCollection(collectionUUID, false); err != nil {
+		return err
+	}
+
+	db := GetDB()
+	err := db.Transaction(func(tx *gorm.DB) error {
+		return deleteGISCollection(tx, collectionUUID)
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Println("GIS collection deleted:", collectionUUID)
+	return nil
+}
+
+// ImportGISCollections stores the given collections under a provider, skipping UUIDs that already exist
+// and collections that fail validation.
+// It returns the number of imported collections.
+func ImportGISCollections(providerUUID string, collections gisdata.GISCollections) (int, error) {
+	imported := 0
+	for _, c := range collections {
+		var data gisdata.GISData
+		if c.GIS != nil {
+			data = *c.GIS
+		}
+
+		_, err := CreateGISCollection(c.UUID, c.Name, providerUUID, "", data)
+		if errors.Is(err, ErrExists) {
+			log.Println("Skipping existing GIS collection:", c.UUID)
+			continue
+		}
+		if errors.Is(err, validator.ErrInvalidGISData) {
+			log.Printf("Skipping invalid GIS collection %s: %v", c.UUID, err)
+			continue
+		}
+		if err != nil {
+			return imported, err
+		}
+		imported++
+	}
+
+	return imported, nil
+}
+
+func preloadGISElements(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Points", orderBySeq).
+		Preload("Lines", orderBySeq).
+		Preload("Polylines", orderBySeq).
+		Preload("Polygons", orderBySeq)
+}
+
+func orderBySeq(db *gorm.DB) *gorm.DB {
+	return db.Order("seq")
+}
+
+func deleteGISCollection(tx *gorm.DB, collectionUUID string) error {
+	if err := deleteGISElements(tx, collectionUUID); err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Where("collection_uuid = ?", collectionUUID).Delete(&GISCollectionRevision{}).Error; err != nil {
+		return err
+	}
+
+	return tx.Unscoped().Where("uuid = ?", collectionUUID).Delete(&GISCollection{}).Error
+}
+
+func deleteGISElements(tx *gorm.DB, collectionUUID string) error {
+	for _, model := range []any{&GISCollectionPoint{}, &GISCollectionLine{}, &GISCollectionPolyline{}, &GISCollectionPolygon{}} {
+		if err := tx.Where("collection_uuid = ?", collectionUUID).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func insertGISElements(tx *gorm.DB, c *GISCollection) error {
+	if len(c.Points) > 0 {
+		if err := tx.Create(&c.Points).Error; err != nil {
+			return err
+		}
+	}
+	if len(c.Lines) > 0 {
+		if err := tx.Create(&c.Lines).Error; err != nil {
+			return err
+		}
+	}
+	if len(c.Polylines) > 0 {
+		if err := tx.Create(&c.Polylines).Error; err != nil {
+			return err
+		}
+	}
+	if len(c.Polygons) > 0 {
+		if err := tx.Create(&c.Polygons).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
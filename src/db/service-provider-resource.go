@@ -0,0 +1,91 @@
+// Package db
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-19
+package db
+
+import "log"
+
+// RegisterProviderResource records that a provider owns the given resource.
+func RegisterProviderResource(providerUUID string, kind ResourceKind, resourceUUID string) error {
+	db := GetDB()
+
+	var existing ProviderResource
+	err := db.Where("provider_uuid = ? AND kind = ? AND resource_uuid = ?", providerUUID, kind, resourceUUID).
+		First(&existing).Error
+	if err == nil {
+		return ErrExists
+	}
+
+	resource := ProviderResource{
+		ProviderUUID: providerUUID,
+		Kind:         kind,
+		ResourceUUID: resourceUUID,
+	}
+	if err := db.Create(&resource).Error; err != nil {
+		return err
+	}
+
+	log.Println("Registered", kind, resourceUUID, "under provider", providerUUID)
+	return nil
+}
+
+// GetResourceProviderUUIDs returns the UUIDs of the providers that own a resource.
+// Collections are owned through their ProviderUUID column.
+func GetResourceProviderUUIDs(kind ResourceKind, resourceUUID string) ([]string, error) {
+	db := GetDB()
+
+	if kind == ResourceCollection {
+		var uuids []string
+		err := db.Model(&GISCollection{}).
+			Where("uuid = ?", resourceUUID).
+			Pluck("provider_uuid", &uuids).Error
+		if err != nil {
+			return nil, err
+		}
+		return uuids, nil
+	}
+
+	var uuids []string
+	err := db.Model(&ProviderResource{}).
+		Where("kind = ? AND resource_uuid = ?", kind, resourceUUID).
+		Pluck("provider_uuid", &uuids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return uuids, nil
+}
+
+// ListResourceUUIDs returns the UUIDs of the resources of a kind owned by any of the providers.
+func ListResourceUUIDs(kind ResourceKind, providerUUIDs []string) ([]string, error) {
+	db := GetDB()
+
+	var uuids []string
+	err := db.Model(&ProviderResource{}).
+		Where("kind = ? AND provider_uuid IN ?", kind, providerUUIDs).
+		Distinct().
+		Pluck("resource_uuid", &uuids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return uuids, nil
+}
+
+// UnregisterProviderResource removes a resource from a provider. The row is deleted for good
+// so the unique index lets the resource be registered again.
+func UnregisterProviderResource(providerUUID string, kind ResourceKind, resourceUUID string) error {
+	db := GetDB()
+
+	result := db.Unscoped().Where("provider_uuid = ? AND kind = ? AND resource_uuid = ?", providerUUID, kind, resourceUUID).
+		Delete(&ProviderResource{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	log.Println("Unregistered", kind, resourceUUID, "from provider", providerUUID)
+	return nil
+}
@@ -0,0 +1,32 @@
+// Package db
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-19
+package db
+
+import "testing"
+
+func TestProviderResourceRegisterAgain(t *testing.T) {
+	useTestDB(t)
+
+	const provider, network = "provider-1", "network-1"
+	if err := RegisterProviderResource(provider, ResourceNetwork, network); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if err := RegisterProviderResource(provider, ResourceNetwork, network); err != ErrExists {
+		t.Fatalf("Expected ErrExists, got %v", err)
+	}
+	if err := UnregisterProviderResource(provider, ResourceNetwork, network); err != nil {
+		t.Fatalf("Unregister failed: %v", err)
+	}
+	if err := UnregisterProviderResource(provider, ResourceNetwork, network); err != ErrNotFound {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+	if err := RegisterProviderResource(provider, ResourceNetwork, network); err != nil {
+		t.Fatalf("Register after unregister failed: %v", err)
+	}
+
+	uuids, err := ListResourceUUIDs(ResourceNetwork, []string{provider})
+	if err != nil || len(uuids) != 1 || uuids[0] != network {
+		t.Errorf("Expected [%s], got %v, %v", network, uuids, err)
+	}
+}
@@ -4,19 +4,35 @@
 package db
 
 import (
+	"errors"
 	"log"
+
+	"gorm.io/gorm"
 )
 
-// RegisterNewProvider creates a new provider entry.
-func RegisterNewProvider(host string, port int, dbUser, dbPassword string) (Provider, error) {
+// RegisterNewProvider creates a new provider entry and makes ownerUUID its admin.
+// Both are written in one transaction, so a provider is never left without an owner.
+func RegisterNewProvider(ownerUUID, name, host string, port int, dbUser, dbPassword string) (Provider, error) {
+	if !HasMasterKey() {
+		return Provider{}, ErrMasterKeyMissing
+	}
+
 	db := GetDB()
 	provider := Provider{
+		Name:       name,
 		Host:       host,
 		Port:       port,
-		DBUser:     dbUser,
-		DBPassword: dbPassword,
+		DBUser:     EncryptedString(dbUser),
+		DBPassword: EncryptedString(dbPassword),
 	}
-	if err := db.Create(&provider).Error; err != nil {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&provider).Error; err != nil {
+			return err
+		}
+
+		return linkProvider(tx, ownerUUID, provider.UUID, RoleAdmin)
+	})
+	if err != nil {
 		return Provider{}, err
 	}
 
@@ -29,7 +45,92 @@ func GetProviderDetails(providerUUID string) (Provider, error) {
 	db := GetDB()
 	var provider Provider
 	if err := db.Where("uuid = ?", providerUUID).First(&provider).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Provider{}, ErrNotFound
+		}
+		return Provider{}, err
+	}
+	return provider, nil
+}
+
+// ProviderUpdate lists the provider fields to change. Nil fields are left unchanged.
+type ProviderUpdate struct {
+	Name       *string
+	Host       *string
+	Port       *int
+	DBUser     *string
+	DBPassword *string
+}
+
+// UpdateProvider applies the given changes to a provider.
+func UpdateProvider(providerUUID string, update ProviderUpdate) (Provider, error) {
+	provider, err := GetProviderDetails(providerUUID)
+	if err != nil {
+		return Provider{}, err
+	}
+
+	if update.DBUser != nil || update.DBPassword != nil {
+		if !HasMasterKey() {
+			return Provider{}, ErrMasterKeyMissing
+		}
+	}
+
+	if update.Name != nil {
+		provider.Name = *update.Name
+	}
+	if update.Host != nil {
+		provider.Host = *update.Host
+	}
+	if update.Port != nil {
+		provider.Port = *update.Port
+	}
+	if update.DBUser != nil {
+		provider.DBUser = EncryptedString(*update.DBUser)
+	}
+	if update.DBPassword != nil {
+		provider.DBPassword = EncryptedString(*update.DBPassword)
+	}
+
+	db := GetDB()
+	if err := db.Save(&provider).Error; err != nil {
 		return Provider{}, err
 	}
+
+	log.Println("Provider updated:", provider.UUID)
 	return provider, nil
 }
+
+// DeleteProvider permanently removes a provider together with its user links, resources and collections.
+func DeleteProvider(providerUUID string) error {
+	if _, err := GetProviderDetails(providerUUID); err != nil {
+		return err
+	}
+
+	db := GetDB()
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("provider_uuid = ?", providerUUID).Delete(&UserProvider{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("provider_uuid = ?", providerUUID).Delete(&ProviderResource{}).Error; err != nil {
+			return err
+		}
+
+		var collectionUUIDs []string
+		if err := tx.Model(&GISCollection{}).Where("provider_uuid = ?", providerUUID).
+			Pluck("uuid", &collectionUUIDs).Error; err != nil {
+			return err
+		}
+		for _, collectionUUID := range collectionUUIDs {
+			if err := deleteGISCollection(tx, collectionUUID); err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Where("uuid = ?", providerUUID).Delete(&Provider{}).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Println("Provider deleted:", providerUUID)
+	return nil
+}
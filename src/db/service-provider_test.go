@@ -0,0 +1,34 @@
+// Package db
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-2월-11
+package db
+
+import "testing"
+
+func TestRegisterNewProviderLinksOwner(t *testing.T) {
+	useTestDB(t)
+	if err := SetMasterKey("test-master-key"); err != nil {
+		t.Fatal(err)
+	}
+
+	provider, err := RegisterNewProvider("user-1", "plant", "localhost", 5432, "gis", "secret")
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if role, err := GetUserProviderRole("user-1", provider.UUID); err != nil || role != RoleAdmin {
+		t.Errorf("Expected the owner to be admin, got %q, %v", role, err)
+	}
+
+	// When the owner cannot be linked, the provider must not be kept either.
+	if err := GetDB().Migrator().DropTable(&UserProvider{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RegisterNewProvider("user-1", "orphan", "localhost", 5432, "gis", "secret"); err == nil {
+		t.Fatal("Expected the link failure")
+	}
+
+	var count int64
+	if err := GetDB().Model(&Provider{}).Count(&count).Error; err != nil || count != 1 {
+		t.Errorf("Expected only the first provider, got %d, %v", count, err)
+	}
+}
@@ -0,0 +1,59 @@
+// Package db
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-18
+package db
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CreateRefreshToken stores a new refresh token hash for a user.
+func CreateRefreshToken(userUUID, tokenHash string, expiresAt time.Time) (RefreshToken, error) {
+	db := GetDB()
+	token := RefreshToken{
+		UserUUID:  userUUID,
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+	}
+	if err := db.Create(&token).Error; err != nil {
+		return RefreshToken{}, err
+	}
+
+	return token, nil
+}
+
+// GetRefreshToken fetches a refresh token by its hash.
+func GetRefreshToken(tokenHash string) (RefreshToken, error) {
+	db := GetDB()
+	var token RefreshToken
+	if err := db.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return RefreshToken{}, ErrNotFound
+		}
+		return RefreshToken{}, err
+	}
+
+	return token, nil
+}
+
+// RevokeRefreshToken marks a refresh token as revoked. It returns how many tokens it revoked,
+// which is zero when the token was already revoked, for instance by a concurrent call.
+func RevokeRefreshToken(tokenHash string) (int64, error) {
+	db := GetDB()
+	result := db.Model(&RefreshToken{}).
+		Where("token_hash = ? AND revoked_at IS NULL", tokenHash).
+		Update("revoked_at", time.Now())
+
+	return result.RowsAffected, result.Error
+}
+
+// RevokeUserRefreshTokens revokes every active refresh token of a user.
+func RevokeUserRefreshTokens(userUUID string) error {
+	db := GetDB()
+	return db.Model(&RefreshToken{}).
+		Where("user_uuid = ? AND revoked_at IS NULL", userUUID).
+		Update("revoked_at", time.Now()).Error
+}
@@ -0,0 +1,25 @@
+// Package db
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-18
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRevokeRefreshTokenOnce(t *testing.T) {
+	useTestDB(t)
+
+	if _, err := CreateRefreshToken("user-1", "hash-1", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if revoked, err := RevokeRefreshToken("hash-1"); err != nil || revoked != 1 {
+		t.Fatalf("Expected the token to be revoked, got %d, %v", revoked, err)
+	}
+	// A second revocation, as a concurrent refresh would attempt, must not count.
+	if revoked, err := RevokeRefreshToken("hash-1"); err != nil || revoked != 0 {
+		t.Errorf("Expected nothing left to revoke, got %d, %v", revoked, err)
+	}
+}
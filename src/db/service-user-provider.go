@@ -3,7 +3,12 @@
 // Author: teocci@yandex.com on 2025-2월-11
 package db
 
-import "log"
+import (
+	"errors"
+	"log"
+
+	"gorm.io/gorm"
+)
 
 // GetProvidersByUserUUID retrieves all providers linked to a given user.
 func GetProvidersByUserUUID(userUUID string) ([]Provider, error) {
@@ -30,10 +35,17 @@ func GetProvidersByUserUUID(userUUID string) ([]Provider, error) {
 	return providers, nil
 }
 
-// LinkProviderWithUser links a provider to a user.
+// LinkProviderWithUser links a provider to a user with viewer access.
 func LinkProviderWithUser(userUUID, providerUUID string) error {
-	db := GetDB()
+	return LinkProviderWithUserRole(userUUID, providerUUID, RoleViewer)
+}
 
+// LinkProviderWithUserRole links a provider to a user with the given access level.
+func LinkProviderWithUserRole(userUUID, providerUUID string, role Role) error {
+	return linkProvider(GetDB(), userUUID, providerUUID, role)
+}
+
+func linkProvider(db *gorm.DB, userUUID, providerUUID string, role Role) error {
 	// Check if link already exists
 	var existing UserProvider
 	if err := db.Where("user_uuid = ? AND provider_uuid = ?", userUUID, providerUUID).First(&existing).Error; err == nil {
@@ -43,12 +55,29 @@ func LinkProviderWithUser(userUUID, providerUUID string) error {
 	userProvider := UserProvider{
 		UserUUID:     userUUID,
 		ProviderUUID: providerUUID,
+		Role:         role,
 	}
 
-	if err := db.Create(&userProvider).Error; err != nil {
+	if err := db.Omit("User", "Provider").Create(&userProvider).Error; err != nil {
 		return err
 	}
 
 	log.Println("Linked user", userUUID, "to provider", providerUUID)
 	return nil
 }
+
+// GetUserProviderRole returns the access level a user was granted over a provider.
+func GetUserProviderRole(userUUID, providerUUID string) (Role, error) {
+	db := GetDB()
+
+	var userProvider UserProvider
+	err := db.Where("user_uuid = ? AND provider_uuid = ?", userUUID, providerUUID).First(&userProvider).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+
+	return userProvider.Role, nil
+}
@@ -0,0 +1,210 @@
+// Package db
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-17
+package db
+
+import (
+	"errors"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// GetUserByUUID fetches a user by UUID.
+func GetUserByUUID(userUUID string) (User, error) {
+	db := GetDB()
+	var user User
+	if err := db.Where("uuid = ?", userUUID).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return User{}, ErrNotFound
+		}
+		return User{}, err
+	}
+	return user, nil
+}
+
+// GetUserByUsername fetches a user by username.
+func GetUserByUsername(username string) (User, error) {
+	db := GetDB()
+	var user User
+	if err := db.Where("username = ?", username).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return User{}, ErrNotFound
+		}
+		return User{}, err
+	}
+	return user, nil
+}
+
+// CreateUser registers a new user with a hashed password.
+func CreateUser(name, username, password string, role Role) (User, error) {
+	db := GetDB()
+
+	if _, err := GetUserByUsername(username); err == nil {
+		return User{}, ErrUsernameTaken
+	}
+	if _, ok := ParseRole(string(role)); !ok {
+		return User{}, ErrInvalidRole
+	}
+
+	user := User{
+		Name:     name,
+		Username: username,
+		Role:     role,
+	}
+	if err := user.SetPassword(password); err != nil {
+		return User{}, err
+	}
+
+	if err := db.Create(&user).Error; err != nil {
+		return User{}, err
+	}
+
+	log.Println("User added:", user.Username)
+	return user, nil
+}
+
+// ListUsers returns every user ordered by username.
+func ListUsers() ([]User, error) {
+	db := GetDB()
+	var users []User
+	if err := db.Order("username").Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// UpdateUserRole changes the global role of a user.
+func UpdateUserRole(userUUID string, role Role) (User, error) {
+	if role == "" {
+		return User{}, ErrInvalidRole
+	}
+
+	return UpdateUser(userUUID, "", role)
+}
+
+// UpdateUser changes the display name and the global role of a user in a single save, so
+// neither is written when the other is refused. Empty values are left as they are.
+func UpdateUser(userUUID, name string, role Role) (User, error) {
+	if role != "" {
+		if _, ok := ParseRole(string(role)); !ok {
+			return User{}, ErrInvalidRole
+		}
+	}
+
+	return updateUser(userUUID, func(u *User) error {
+		if role != "" {
+			if u.IsAdmin() && role != RoleAdmin {
+				if err := ensureOtherAdmin(u.UUID); err != nil {
+					return err
+				}
+			}
+			u.Role = role
+		}
+		if name != "" {
+			u.Name = name
+		}
+		return nil
+	})
+}
+
+// ChangeUserPassword replaces the password of a user and ends their sessions.
+func ChangeUserPassword(userUUID, password string) error {
+	_, err := updateUser(userUUID, func(u *User) error {
+		return u.SetPassword(password)
+	})
+	if err != nil {
+		return err
+	}
+
+	return RevokeUserRefreshTokens(userUUID)
+}
+
+// SetUserDisabled enables or disables a user. Disabling also ends their sessions.
+func SetUserDisabled(userUUID string, disabled bool) (User, error) {
+	user, err := updateUser(userUUID, func(u *User) error {
+		if disabled && u.IsAdmin() {
+			if err := ensureOtherAdmin(u.UUID); err != nil {
+				return err
+			}
+		}
+		u.Disabled = disabled
+		return nil
+	})
+	if err != nil {
+		return User{}, err
+	}
+
+	if disabled {
+		if err := RevokeUserRefreshTokens(userUUID); err != nil {
+			return User{}, err
+		}
+	}
+
+	return user, nil
+}
+
+// DeleteUser permanently removes a user along with their provider links and refresh tokens.
+func DeleteUser(userUUID string) error {
+	user, err := GetUserByUUID(userUUID)
+	if err != nil {
+		return err
+	}
+	if user.IsAdmin() {
+		if err := ensureOtherAdmin(user.UUID); err != nil {
+			return err
+		}
+	}
+
+	db := GetDB()
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("user_uuid = ?", userUUID).Delete(&UserProvider{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("user_uuid = ?", userUUID).Delete(&RefreshToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("uuid = ?", userUUID).Delete(&User{}).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Println("User deleted:", user.Username)
+	return nil
+}
+
+func updateUser(userUUID string, apply func(u *User) error) (User, error) {
+	user, err := GetUserByUUID(userUUID)
+	if err != nil {
+		return User{}, err
+	}
+
+	if err := apply(&user); err != nil {
+		return User{}, err
+	}
+
+	db := GetDB()
+	if err := db.Save(&user).Error; err != nil {
+		return User{}, err
+	}
+
+	return user, nil
+}
+
+// ensureOtherAdmin fails when the given user is the only active admin left.
+func ensureOtherAdmin(userUUID string) error {
+	db := GetDB()
+	var count int64
+	err := db.Model(&User{}).
+		Where("role = ? AND disabled = ? AND uuid <> ?", RoleAdmin, false, userUUID).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrLastAdmin
+	}
+
+	return nil
+}
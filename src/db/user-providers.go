@@ -6,11 +6,13 @@ package db
 import "gorm.io/gorm"
 
 // UserProvider represents the many-to-many relationship between users and providers.
+// Role limits what the user may do with the provider's resources.
 type UserProvider struct {
 	gorm.Model
 	UserUUID     string `gorm:"type:char(36);not null;index"`
 	ProviderUUID string `gorm:"type:char(36);not null;index"`
-	
-	User     `gorm:"foreignKey:UserUUID;references:UUID;index"`
-	Provider `gorm:"foreignKey:ProviderUUID;references:UUID;index"`
+	Role         Role   `gorm:"type:varchar(16);not null;default:viewer"`
+
+	User     User     `gorm:"foreignKey:UserUUID;references:UUID"`
+	Provider Provider `gorm:"foreignKey:ProviderUUID;references:UUID"`
 }
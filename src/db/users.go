@@ -16,6 +16,8 @@ type User struct {
 	Name         string `gorm:"not null"`
 	Username     string `gorm:"unique;not null"`
 	PasswordHash string `gorm:"not null"`
+	Role         Role   `gorm:"type:varchar(16);not null;default:viewer"`
+	Disabled     bool   `gorm:"not null;default:false"`
 }
 
 // BeforeCreate hook to generate UUID
@@ -24,6 +26,28 @@ func (u *User) BeforeCreate(tx *gorm.DB) (err error) {
 	return
 }
 
+// IsAdmin reports whether the user holds the global admin role.
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
+
+// SetPassword hashes and stores a new password.
+func (u *User) SetPassword(password string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	u.PasswordHash = string(hash)
+	return nil
+}
+
+// CheckPassword reports whether the password matches the stored hash.
+func (u *User) CheckPassword(password string) bool {
+	err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password))
+	return err == nil
+}
+
 func (u *User) CheckPasswordHash(hash string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(u.PasswordHash))
 	return err == nil
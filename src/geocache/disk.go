@@ -0,0 +1,125 @@
+// Package geocache
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-10
+package geocache
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const diskExt = ".json.gz"
+
+// disk keeps the encoded entries as gzip files named after their key.
+// The modification time of a file is the time its data was fetched.
+type disk struct {
+	dir string
+}
+
+func (d *disk) path(key Key) string {
+	return filepath.Join(d.dir, key.path()+diskExt)
+}
+
+// read returns the encoded entry and when it was fetched.
+func (d *disk) read(key Key) ([]byte, time.Time, error) {
+	f, err := os.Open(d.path(key))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer zr.Close()
+
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return data, info.ModTime(), nil
+}
+
+// modified returns when the entry was fetched without reading it.
+func (d *disk) modified(key Key) (time.Time, error) {
+	info, err := os.Stat(d.path(key))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return info.ModTime(), nil
+}
+
+// write replaces the entry atomically so readers never see a partial file.
+func (d *disk) write(key Key, data []byte, modified time.Time) error {
+	path := d.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	zw, _ := gzip.NewWriterLevel(tmp, gzip.BestSpeed)
+	if _, err := zw.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chtimes(tmp.Name(), modified, modified); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// removeNetwork drops the entries of a network, or of every network when network is empty.
+func (d *disk) removeNetwork(profile, network string) error {
+	if profile == "" {
+		return removeContents(d.dir)
+	}
+
+	dir := filepath.Join(d.dir, escape(profile))
+	if network == "" {
+		return os.RemoveAll(dir)
+	}
+
+	return os.RemoveAll(filepath.Join(dir, escape(network)))
+}
+
+func removeContents(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if err := os.RemoveAll(filepath.Join(dir, e.Name())); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
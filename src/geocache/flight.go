@@ -0,0 +1,77 @@
+// Package geocache
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-10
+package geocache
+
+import (
+	"context"
+	"sync"
+)
+
+// flight runs one load per key at a time; callers asking for the same key meanwhile
+// wait for its result instead of starting their own.
+type flight struct {
+	mutex sync.Mutex
+	calls map[string]*call
+}
+
+type call struct {
+	done    chan struct{}
+	item    *item
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// do runs load unless it is already running for the key. A caller whose context ends
+// stops waiting; the load carries on for the others and is cancelled once the last
+// waiter has left.
+func (f *flight) do(ctx context.Context, key string, load func(context.Context) (*item, error)) (*item, error) {
+	f.mutex.Lock()
+	if f.calls == nil {
+		f.calls = map[string]*call{}
+	}
+	c, ok := f.calls[key]
+	if !ok {
+		// The load keeps the values of the first caller but not its deadline.
+		loadCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		c = &call{done: make(chan struct{}), cancel: cancel}
+		f.calls[key] = c
+		go func() {
+			defer cancel()
+			c.item, c.err = load(loadCtx)
+			f.mutex.Lock()
+			if f.calls[key] == c {
+				delete(f.calls, key)
+			}
+			f.mutex.Unlock()
+			close(c.done)
+		}()
+	}
+	c.waiters++
+	f.mutex.Unlock()
+
+	select {
+	case <-c.done:
+		return c.item, c.err
+	case <-ctx.Done():
+		f.leave(key, c)
+		return nil, ctx.Err()
+	}
+}
+
+// leave drops a waiter, cancelling the load when nobody is left to use its result.
+// Later callers for the key then start a new load instead of joining the cancelled one.
+func (f *flight) leave(key string, c *call) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	c.waiters--
+	if c.waiters > 0 {
+		return
+	}
+	c.cancel()
+	if f.calls[key] == c {
+		delete(f.calls, key)
+	}
+}
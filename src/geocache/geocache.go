@@ -0,0 +1,247 @@
+// Package geocache
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-10
+package geocache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/config"
+)
+
+// Key identifies one kind of geometry (nodes, links...) of a network served by a profile.
+type Key struct {
+	Profile string
+	Network string
+	Kind    string
+}
+
+// Meta describes a cached entry for HTTP validation.
+type Meta struct {
+	ETag     string
+	Modified time.Time
+}
+
+// Stats reports the memory use of the cache.
+type Stats struct {
+	Entries   int    `json:"entries"`
+	Bytes     int64  `json:"bytes"`
+	MaxBytes  int64  `json:"maxBytes"`
+	TTL       string `json:"ttl"`
+	Directory string `json:"directory,omitempty"`
+}
+
+type item struct {
+	value    any
+	size     int64
+	etag     string
+	modified time.Time
+}
+
+// Cache keeps decoded upstream geometry in an LRU bounded by the encoded size of
+// its entries and, optionally, on disk.
+type Cache struct {
+	ttl    time.Duration
+	mem    *lru
+	disk   *disk
+	flight flight
+}
+
+var (
+	shared      = New(config.CacheSetup{})
+	sharedMutex sync.RWMutex
+)
+
+// New creates a cache; unset fields of the setup take their defaults.
+func New(setup config.CacheSetup) *Cache {
+	setup = setup.WithDefaults()
+
+	c := &Cache{
+		ttl: setup.TTL,
+		mem: newLRU(int64(setup.MaxMemoryMB) << 20),
+	}
+	if setup.Directory != "" {
+		c.disk = &disk{dir: setup.Directory}
+	}
+
+	return c
+}
+
+// Init replaces the shared cache.
+func Init(setup config.CacheSetup) {
+	sharedMutex.Lock()
+	defer sharedMutex.Unlock()
+
+	shared = New(setup)
+}
+
+// Default returns the shared cache.
+func Default() *Cache {
+	sharedMutex.RLock()
+	defer sharedMutex.RUnlock()
+
+	return shared
+}
+
+// Get returns the cached value of the key, calling fetch when it is missing or older than
+// the TTL. Concurrent calls for the same key share a single fetch, which outlives the
+// caller that started it and is only cancelled once every caller waiting on it has gone.
+func Get[T any](ctx context.Context, c *Cache, key Key, fetch func(context.Context) (T, error)) (T, Meta, error) {
+	var zero T
+
+	name := key.String()
+	if it, ok := c.mem.get(name); ok && c.fresh(it) {
+		if v, ok := it.value.(T); ok {
+			return v, it.meta(), nil
+		}
+	}
+
+	it, err := c.flight.do(ctx, name, func(ctx context.Context) (*item, error) {
+		return load(ctx, c, key, fetch)
+	})
+	if err != nil {
+		return zero, Meta{}, err
+	}
+
+	v, ok := it.value.(T)
+	if !ok {
+		return zero, Meta{}, fmt.Errorf("cache entry %s holds %T", name, it.value)
+	}
+
+	return v, it.meta(), nil
+}
+
+func load[T any](ctx context.Context, c *Cache, key Key, fetch func(context.Context) (T, error)) (*item, error) {
+	name := key.String()
+
+	if c.disk != nil {
+		if data, modified, err := c.disk.read(key); err == nil && time.Since(modified) < c.ttl {
+			value := new(T)
+			if err := json.Unmarshal(data, value); err == nil {
+				it := newItem(*value, data, modified)
+				c.mem.add(name, it)
+				return it, nil
+			}
+		}
+	}
+
+	value, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	it := newItem(value, data, time.Now())
+	c.mem.add(name, it)
+	if c.disk != nil {
+		if err := c.disk.write(key, data, it.modified); err != nil {
+			log.Printf("geocache: cannot store %s on disk: %v", name, err)
+		}
+	}
+
+	return it, nil
+}
+
+func newItem(value any, data []byte, modified time.Time) *item {
+	h := fnv.New64a()
+	_, _ = h.Write(data)
+
+	return &item{
+		value:    value,
+		size:     int64(len(data)),
+		etag:     fmt.Sprintf("%016x", h.Sum64()),
+		modified: modified.UTC().Truncate(time.Second),
+	}
+}
+
+func (it *item) meta() Meta {
+	return Meta{ETag: it.etag, Modified: it.modified}
+}
+
+// Cached reports whether the key has a fresh entry in memory or on disk, so Get
+// would not call the backend.
+func (c *Cache) Cached(key Key) bool {
+	if it, ok := c.mem.get(key.String()); ok && c.fresh(it) {
+		return true
+	}
+	if c.disk != nil {
+		if modified, err := c.disk.modified(key); err == nil && time.Since(modified) < c.ttl {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Cache) fresh(it *item) bool {
+	return time.Since(it.modified) < c.ttl
+}
+
+// Invalidate drops the entries of a network of a profile. An empty network drops every
+// network of the profile and an empty profile drops everything.
+func (c *Cache) Invalidate(profile, network string) (int, error) {
+	prefix := ""
+	if profile != "" {
+		prefix = escape(profile) + "/"
+		if network != "" {
+			prefix += escape(network) + "/"
+		}
+	}
+
+	removed := c.mem.removePrefix(prefix)
+	if c.disk != nil {
+		if err := c.disk.removeNetwork(profile, network); err != nil {
+			return removed, err
+		}
+	}
+
+	return removed, nil
+}
+
+// Stats reports the memory use of the cache.
+func (c *Cache) Stats() Stats {
+	entries, used := c.mem.stats()
+	stats := Stats{Entries: entries, Bytes: used, MaxBytes: c.mem.maxBytes, TTL: c.ttl.String()}
+	if c.disk != nil {
+		stats.Directory = c.disk.dir
+	}
+
+	return stats
+}
+
+func (k Key) String() string {
+	return escape(k.Profile) + "/" + escape(k.Network) + "/" + escape(k.Kind)
+}
+
+func (k Key) path() string {
+	return filepath.Join(escape(k.Profile), escape(k.Network), escape(k.Kind))
+}
+
+// escape keeps letters, digits, '-' and '_' and percent-encodes everything else,
+// so keys are safe file names and cannot climb out of the cache directory.
+func escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case ch >= 'a' && ch <= 'z', ch >= 'A' && ch <= 'Z', ch >= '0' && ch <= '9', ch == '-', ch == '_':
+			b.WriteByte(ch)
+		default:
+			fmt.Fprintf(&b, "%%%02X", ch)
+		}
+	}
+
+	return b.String()
+}
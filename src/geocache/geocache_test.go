@@ -0,0 +1,131 @@
+// Package geocache
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-10
+package geocache
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/config"
+)
+
+func TestGetSharesFetches(t *testing.T) {
+	c := New(config.CacheSetup{})
+	key := Key{Profile: "dev", Network: "n1", Kind: "nodes"}
+
+	var fetches atomic.Int32
+	fetch := func(context.Context) ([]int, error) {
+		fetches.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		return []int{1, 2, 3}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, _, err := Get(context.Background(), c, key, fetch); err != nil || len(v) != 3 {
+				t.Errorf("Unexpected result %v, %v", v, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	_, meta, _ := Get(context.Background(), c, key, fetch)
+	if fetches.Load() != 1 {
+		t.Errorf("Expected a single fetch, got %d", fetches.Load())
+	}
+	if meta.ETag == "" || meta.Modified.IsZero() {
+		t.Errorf("Expected validators, got %+v", meta)
+	}
+}
+
+func TestGetCancelsAbandonedFetch(t *testing.T) {
+	c := New(config.CacheSetup{})
+	key := Key{Profile: "dev", Network: "n1", Kind: "links"}
+
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	fetch := func(ctx context.Context) ([]int, error) {
+		close(started)
+		<-ctx.Done()
+		close(cancelled)
+		return nil, ctx.Err()
+	}
+
+	first, cancelFirst := context.WithCancel(context.Background())
+	second, cancelSecond := context.WithCancel(context.Background())
+	errs := make(chan error, 2)
+	go func() { _, _, err := Get(first, c, key, fetch); errs <- err }()
+	<-started
+	go func() { _, _, err := Get(second, c, key, fetch); errs <- err }()
+	time.Sleep(10 * time.Millisecond)
+
+	cancelFirst()
+	<-errs
+	select {
+	case <-cancelled:
+		t.Fatal("Expected the fetch to carry on while a caller still waits")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cancelSecond()
+	<-errs
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the fetch to be cancelled once every caller left")
+	}
+}
+
+func TestLRUEvictsBySize(t *testing.T) {
+	l := newLRU(10)
+	l.add("a", &item{size: 4})
+	l.add("b", &item{size: 4})
+	l.get("a")
+	l.add("c", &item{size: 4})
+
+	if _, ok := l.get("b"); ok {
+		t.Errorf("Expected the least recently used entry to be evicted")
+	}
+	if _, ok := l.get("a"); !ok {
+		t.Errorf("Expected the recently used entry to stay")
+	}
+}
+
+func TestDiskAndInvalidate(t *testing.T) {
+	setup := config.CacheSetup{Directory: t.TempDir()}
+	key := Key{Profile: "dev", Network: "../n1", Kind: "links"}
+	fetched := 0
+	fetch := func(context.Context) ([]string, error) {
+		fetched++
+		return []string{"x"}, nil
+	}
+
+	_, first, _ := Get(context.Background(), New(setup), key, fetch)
+
+	// A new cache over the same directory reads the entry back from disk.
+	c := New(setup)
+	if !c.Cached(key) {
+		t.Fatalf("Expected the disk entry to count as cached")
+	}
+	_, second, err := Get(context.Background(), c, key, fetch)
+	if err != nil || fetched != 1 || second.ETag != first.ETag {
+		t.Fatalf("Expected the disk entry, got %d fetches, %+v, %v", fetched, second, err)
+	}
+
+	if n, err := c.Invalidate("dev", "../n1"); err != nil || n != 1 {
+		t.Fatalf("Expected one entry invalidated, got %d, %v", n, err)
+	}
+	if c.Cached(key) {
+		t.Errorf("Expected no entry after invalidation")
+	}
+	if _, _, _ = Get(context.Background(), c, key, fetch); fetched != 2 {
+		t.Errorf("Expected a fetch after invalidation, got %d", fetched)
+	}
+}
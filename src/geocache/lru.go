@@ -0,0 +1,99 @@
+// Package geocache
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-10
+package geocache
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+)
+
+// lru keeps items up to a total size, evicting the least recently used first.
+type lru struct {
+	maxBytes int64
+
+	mutex sync.Mutex
+	used  int64
+	order *list.List
+	items map[string]*list.Element
+}
+
+type lruItem struct {
+	key  string
+	item *item
+}
+
+func newLRU(maxBytes int64) *lru {
+	return &lru{maxBytes: maxBytes, order: list.New(), items: map[string]*list.Element{}}
+}
+
+func (l *lru) get(key string) (*item, bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	e, ok := l.items[key]
+	if !ok {
+		return nil, false
+	}
+	l.order.MoveToFront(e)
+
+	return e.Value.(*lruItem).item, true
+}
+
+// add stores the item unless it alone is larger than the cache.
+func (l *lru) add(key string, it *item) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.removeLocked(key)
+	if it.size > l.maxBytes {
+		return
+	}
+
+	l.items[key] = l.order.PushFront(&lruItem{key: key, item: it})
+	l.used += it.size
+	for l.used > l.maxBytes {
+		l.removeLocked(l.order.Back().Value.(*lruItem).key)
+	}
+}
+
+func (l *lru) remove(key string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.removeLocked(key)
+}
+
+// removePrefix drops every key starting with prefix; an empty prefix clears the cache.
+func (l *lru) removePrefix(prefix string) int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	removed := 0
+	for key := range l.items {
+		if strings.HasPrefix(key, prefix) {
+			l.removeLocked(key)
+			removed++
+		}
+	}
+
+	return removed
+}
+
+func (l *lru) stats() (int, int64) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return len(l.items), l.used
+}
+
+func (l *lru) removeLocked(key string) {
+	e, ok := l.items[key]
+	if !ok {
+		return
+	}
+	l.order.Remove(e)
+	delete(l.items, key)
+	l.used -= e.Value.(*lruItem).item.size
+}
@@ -0,0 +1,13 @@
+// Package geojson
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-28
+package geojson
+
+import "errors"
+
+var (
+	ErrNotFeatureCollection = errors.New("GeoJSON object must be a FeatureCollection")
+	ErrUnsupportedGeometry  = errors.New("unsupported GeoJSON geometry type")
+	ErrInvalidPosition      = errors.New("GeoJSON position must have 2 or 3 coordinates")
+	ErrEmptyGeometry        = errors.New("GeoJSON geometry has no coordinates")
+)
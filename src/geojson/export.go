@@ -0,0 +1,168 @@
+// Package geojson
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-28
+package geojson
+
+import (
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+	"github.com/teocci/go-hynix-3d-viewer/src/gisdata"
+)
+
+const (
+	KindPoint    = "point"
+	KindLine     = "line"
+	KindPolyline = "polyline"
+	KindPolygon  = "polygon"
+)
+
+// FromGISCollections exports collections as a single feature collection.
+// Every feature carries the uuid and name of its collection in its properties.
+func FromGISCollections(collections gisdata.GISCollections) FeatureCollection {
+	fc := NewFeatureCollection("")
+	if len(collections) == 1 {
+		fc.Name = collections[0].Name
+	}
+
+	for _, c := range collections {
+		if c.GIS == nil {
+			continue
+		}
+		for _, f := range FromGISData(*c.GIS).Features {
+			f.Properties["collection"] = c.UUID
+			f.Properties["collectionName"] = c.Name
+			fc.Features = append(fc.Features, f)
+		}
+	}
+
+	return fc
+}
+
+// FromGISData maps points to Point, lines and polylines to LineString and polygons to Polygon features.
+// Lines and polylines are resolved through their point ids; unknown ids are skipped.
+func FromGISData(data gisdata.GISData) FeatureCollection {
+	fc := NewFeatureCollection("")
+
+	positions := make(map[string][]float64, len(data.Points))
+	for _, p := range data.Points {
+		positions[p.Id] = p.Coordinates
+		fc.Features = append(fc.Features, NewFeature(p.Id, NewPoint(p.Coordinates), map[string]any{
+			"id":   p.Id,
+			"kind": KindPoint,
+		}))
+	}
+
+	for _, l := range data.Lines {
+		line := resolve(positions, []string{l.Start, l.End})
+		if len(line) < 2 {
+			continue
+		}
+		fc.Features = append(fc.Features, NewFeature(l.Id, NewLineString(line), map[string]any{
+			"id":    l.Id,
+			"kind":  KindLine,
+			"start": l.Start,
+			"end":   l.End,
+		}))
+	}
+
+	for _, pl := range data.Polylines {
+		line := resolve(positions, pl.Nodes)
+		if len(line) < 2 {
+			continue
+		}
+		fc.Features = append(fc.Features, NewFeature(pl.Id, NewLineString(line), map[string]any{
+			"id":    pl.Id,
+			"kind":  KindPolyline,
+			"nodes": pl.Nodes,
+		}))
+	}
+
+	for _, pg := range data.Polygons {
+		if len(pg.Vertices) == 0 {
+			continue
+		}
+		fc.Features = append(fc.Features, NewFeature(pg.Id, NewPolygon([][][]float64{closeRing(pg.Vertices)}), map[string]any{
+			"id":   pg.Id,
+			"kind": KindPolygon,
+		}))
+	}
+
+	return fc
+}
+
+// FromNodes maps network nodes to Point features.
+func FromNodes(nodes gisapi.NodesData) FeatureCollection {
+	fc := NewFeatureCollection("")
+	for _, n := range nodes {
+		fc.Features = append(fc.Features, NodeFeature(n))
+	}
+
+	return fc
+}
+
+// NodeFeature maps a network node to a Point feature.
+func NodeFeature(n gisapi.NodeGeometry) Feature {
+	return NewFeature(n.ID, NewPoint(n.Geometry), map[string]any{
+		"id":   n.ID,
+		"guid": n.Guid,
+		"type": n.Type,
+	})
+}
+
+// FromLinks maps network links to LineString features.
+func FromLinks(links gisapi.LinksData) FeatureCollection {
+	fc := NewFeatureCollection("")
+	for _, l := range links {
+		fc.Features = append(fc.Features, LinkFeature(l))
+	}
+
+	return fc
+}
+
+// LinkFeature maps a network link to a LineString feature.
+func LinkFeature(l gisapi.LinkGeometry) Feature {
+	return NewFeature(l.ID, NewLineString(l.Geometry), map[string]any{
+		"id":          l.ID,
+		"guid":        l.Guid,
+		"type":        l.Type,
+		"sequenceNo":  l.SequenceNo,
+		"startNodeId": l.StartNodeId,
+		"endNodeId":   l.EndNodeId,
+	})
+}
+
+func resolve(positions map[string][]float64, ids []string) [][]float64 {
+	line := make([][]float64, 0, len(ids))
+	for _, id := range ids {
+		if p, ok := positions[id]; ok {
+			line = append(line, p)
+		}
+	}
+
+	return line
+}
+
+// closeRing repeats the first vertex at the end of the ring, as RFC 7946 requires.
+func closeRing(vertices [][]float64) [][]float64 {
+	first, last := vertices[0], vertices[len(vertices)-1]
+	if samePosition(first, last) {
+		return vertices
+	}
+
+	ring := make([][]float64, len(vertices), len(vertices)+1)
+	copy(ring, vertices)
+
+	return append(ring, first)
+}
+
+func samePosition(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,131 @@
+// Package geojson
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-28
+package geojson
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MIMEType is the media type registered for GeoJSON by RFC 7946.
+const MIMEType = "application/geo+json"
+
+const (
+	TypeFeatureCollection = "FeatureCollection"
+	TypeFeature           = "Feature"
+
+	TypePoint           = "Point"
+	TypeMultiPoint      = "MultiPoint"
+	TypeLineString      = "LineString"
+	TypeMultiLineString = "MultiLineString"
+	TypePolygon         = "Polygon"
+	TypeMultiPolygon    = "MultiPolygon"
+)
+
+// FeatureCollection is a GeoJSON feature collection. Name is a common extension member
+// used as the collection name on import. CRS is only set when the coordinates are not
+// WGS84 longitude and latitude, using the named CRS member of the 2008 specification.
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Name     string    `json:"name,omitempty"`
+	CRS      *CRS      `json:"crs,omitempty"`
+	Features []Feature `json:"features"`
+}
+
+// CRS is a named coordinate reference system member.
+type CRS struct {
+	Type       string            `json:"type"`
+	Properties map[string]string `json:"properties"`
+}
+
+// Feature is a GeoJSON feature. A nil Geometry is encoded as null.
+type Feature struct {
+	Type       string         `json:"type"`
+	ID         any            `json:"id,omitempty"`
+	Geometry   *Geometry      `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+// Geometry is a GeoJSON geometry. Coordinates holds []float64 for a Point,
+// [][]float64 for a LineString or MultiPoint, [][][]float64 for a Polygon or MultiLineString
+// and [][][][]float64 for a MultiPolygon.
+type Geometry struct {
+	Type        string `json:"type"`
+	Coordinates any    `json:"coordinates"`
+}
+
+// NewNamedCRS names a CRS by its EPSG code with an OGC URN.
+func NewNamedCRS(code int) *CRS {
+	return &CRS{Type: "name", Properties: map[string]string{"name": fmt.Sprintf("urn:ogc:def:crs:EPSG::%d", code)}}
+}
+
+func NewFeatureCollection(name string) FeatureCollection {
+	return FeatureCollection{Type: TypeFeatureCollection, Name: name, Features: []Feature{}}
+}
+
+func NewFeature(id any, geometry *Geometry, properties map[string]any) Feature {
+	if properties == nil {
+		properties = map[string]any{}
+	}
+
+	return Feature{Type: TypeFeature, ID: id, Geometry: geometry, Properties: properties}
+}
+
+func NewPoint(position []float64) *Geometry {
+	return &Geometry{Type: TypePoint, Coordinates: position}
+}
+
+func NewLineString(positions [][]float64) *Geometry {
+	return &Geometry{Type: TypeLineString, Coordinates: positions}
+}
+
+func NewPolygon(rings [][][]float64) *Geometry {
+	return &Geometry{Type: TypePolygon, Coordinates: rings}
+}
+
+// UnmarshalJSON decodes the coordinates into the slice type matching the geometry type.
+func (g *Geometry) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	var coordinates any
+	switch raw.Type {
+	case TypePoint:
+		coordinates = &[]float64{}
+	case TypeMultiPoint, TypeLineString:
+		coordinates = &[][]float64{}
+	case TypePolygon, TypeMultiLineString:
+		coordinates = &[][][]float64{}
+	case TypeMultiPolygon:
+		coordinates = &[][][][]float64{}
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedGeometry, raw.Type)
+	}
+
+	if len(raw.Coordinates) == 0 {
+		return ErrEmptyGeometry
+	}
+	if err := json.Unmarshal(raw.Coordinates, coordinates); err != nil {
+		return err
+	}
+
+	g.Type = raw.Type
+	switch c := coordinates.(type) {
+	case *[]float64:
+		g.Coordinates = *c
+	case *[][]float64:
+		g.Coordinates = *c
+	case *[][][]float64:
+		g.Coordinates = *c
+	case *[][][][]float64:
+		g.Coordinates = *c
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+// Package geojson
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-28
+package geojson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisdata"
+)
+
+func TestRoundTrip(t *testing.T) {
+	data := gisdata.GISData{
+		Points: []gisdata.GISPoint{
+			{Id: "n1", Coordinates: []float64{0, 0, 0}},
+			{Id: "n2", Coordinates: []float64{10, 0, 0}},
+			{Id: "n3", Coordinates: []float64{10, 10, 0}},
+		},
+		Lines:     []gisdata.GISLine{{Id: "l1", Start: "n1", End: "n2"}},
+		Polylines: []gisdata.GISPolyline{{Id: "pl1", Nodes: []string{"n1", "n2", "n3"}}},
+		Polygons:  []gisdata.GISPolygon{{Id: "pg1", Vertices: [][]float64{{0, 0, 0}, {5, 0, 0}, {5, 5, 0}}}},
+	}
+
+	b, err := json.Marshal(FromGISData(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fc FeatureCollection
+	if err := json.Unmarshal(b, &fc); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ToGISData(fc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d := gisdata.Diff(data, got); !d.Empty() {
+		t.Errorf("Expected the round trip to keep the data, got %+v", d)
+	}
+}
+
+func TestToGISDataCreatesPoints(t *testing.T) {
+	raw := `{"type":"FeatureCollection","features":[
+		{"type":"Feature","id":7,"geometry":{"type":"LineString","coordinates":[[0,0],[1,1],[2,0]]},"properties":{}},
+		{"type":"Feature","geometry":{"type":"Point","coordinates":[1,1,5]},"properties":{"id":"a"}},
+		{"type":"Feature","geometry":null,"properties":{}}
+	]}`
+
+	var fc FeatureCollection
+	if err := json.Unmarshal([]byte(raw), &fc); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ToGISData(fc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(data.Points) != 4 {
+		t.Fatalf("Expected 4 points, got %+v", data.Points)
+	}
+	if len(data.Polylines) != 1 || data.Polylines[0].Id != "7" {
+		t.Fatalf("Expected polyline 7, got %+v", data.Polylines)
+	}
+	if nodes := data.Polylines[0].Nodes; nodes[1] == "a" {
+		t.Errorf("Expected [1,1,0] not to match point a at [1,1,5], got %v", nodes)
+	}
+}
+
+func TestUnsupportedGeometry(t *testing.T) {
+	var g Geometry
+	if err := json.Unmarshal([]byte(`{"type":"GeometryCollection","geometries":[]}`), &g); err == nil {
+		t.Error("Expected an error for GeometryCollection")
+	}
+}
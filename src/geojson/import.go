@@ -0,0 +1,222 @@
+// Package geojson
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-28
+package geojson
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisdata"
+)
+
+// ToGISData converts a feature collection into GIS data.
+// Point features become points. LineString vertices are matched to points with the same position,
+// creating new points where none exist, and become lines when they have two vertices or polylines otherwise.
+// Polygons keep their outer ring only. Multi geometries are split into one element per part.
+// Two-dimensional positions get a zero Z coordinate.
+func ToGISData(fc FeatureCollection) (gisdata.GISData, error) {
+	if fc.Type != TypeFeatureCollection {
+		return gisdata.GISData{}, ErrNotFeatureCollection
+	}
+
+	b := &builder{
+		positions: map[string]string{},
+		used:      map[string]bool{},
+	}
+
+	// Points go first so that line vertices resolve to the ids given in the file.
+	for i, f := range fc.Features {
+		if f.Geometry == nil {
+			continue
+		}
+		if err := b.addPoints(i, f); err != nil {
+			return gisdata.GISData{}, err
+		}
+	}
+
+	for i, f := range fc.Features {
+		if f.Geometry == nil {
+			continue
+		}
+		if err := b.addShapes(i, f); err != nil {
+			return gisdata.GISData{}, err
+		}
+	}
+
+	return b.data, nil
+}
+
+type builder struct {
+	data      gisdata.GISData
+	positions map[string]string
+	used      map[string]bool
+	generated int
+}
+
+func (b *builder) addPoints(index int, f Feature) error {
+	var parts [][]float64
+	switch c := f.Geometry.Coordinates.(type) {
+	case []float64:
+		if f.Geometry.Type == TypePoint {
+			parts = [][]float64{c}
+		}
+	case [][]float64:
+		if f.Geometry.Type == TypeMultiPoint {
+			parts = c
+		}
+	}
+
+	for i, part := range parts {
+		position, err := normalize(part)
+		if err != nil {
+			return fmt.Errorf("feature %d: %w", index, err)
+		}
+
+		id := b.uniqueId(partId(featureId(f), i, len(parts)), "p")
+		b.positions[positionKey(position)] = id
+		b.data.Points = append(b.data.Points, gisdata.GISPoint{Id: id, Coordinates: position})
+	}
+
+	return nil
+}
+
+func (b *builder) addShapes(index int, f Feature) error {
+	id := featureId(f)
+
+	switch f.Geometry.Type {
+	case TypeLineString:
+		return b.addLine(index, f, id, f.Geometry.Coordinates.([][]float64))
+	case TypeMultiLineString:
+		parts := f.Geometry.Coordinates.([][][]float64)
+		for i, part := range parts {
+			if err := b.addLine(index, f, partId(id, i, len(parts)), part); err != nil {
+				return err
+			}
+		}
+	case TypePolygon:
+		return b.addPolygon(index, id, f.Geometry.Coordinates.([][][]float64))
+	case TypeMultiPolygon:
+		parts := f.Geometry.Coordinates.([][][][]float64)
+		for i, part := range parts {
+			if err := b.addPolygon(index, partId(id, i, len(parts)), part); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (b *builder) addLine(index int, f Feature, id string, positions [][]float64) error {
+	nodes := make([]string, 0, len(positions))
+	for _, p := range positions {
+		position, err := normalize(p)
+		if err != nil {
+			return fmt.Errorf("feature %d: %w", index, err)
+		}
+		nodes = append(nodes, b.pointAt(position))
+	}
+
+	if len(nodes) == 2 && f.Properties["kind"] != KindPolyline {
+		b.data.Lines = append(b.data.Lines, gisdata.GISLine{Id: b.uniqueId(id, "l"), Start: nodes[0], End: nodes[1]})
+		return nil
+	}
+
+	b.data.Polylines = append(b.data.Polylines, gisdata.GISPolyline{Id: b.uniqueId(id, "pl"), Nodes: nodes})
+	return nil
+}
+
+func (b *builder) addPolygon(index int, id string, rings [][][]float64) error {
+	if len(rings) == 0 {
+		return fmt.Errorf("feature %d: %w", index, ErrEmptyGeometry)
+	}
+
+	outer := rings[0]
+	vertices := make([][]float64, 0, len(outer))
+	for _, p := range outer {
+		position, err := normalize(p)
+		if err != nil {
+			return fmt.Errorf("feature %d: %w", index, err)
+		}
+		vertices = append(vertices, position)
+	}
+	if len(vertices) > 1 && samePosition(vertices[0], vertices[len(vertices)-1]) {
+		vertices = vertices[:len(vertices)-1]
+	}
+
+	b.data.Polygons = append(b.data.Polygons, gisdata.GISPolygon{Id: b.uniqueId(id, "pg"), Vertices: vertices})
+	return nil
+}
+
+// pointAt returns the id of the point at the given position, creating it when needed.
+func (b *builder) pointAt(position []float64) string {
+	key := positionKey(position)
+	if id, ok := b.positions[key]; ok {
+		return id
+	}
+
+	id := b.uniqueId("", "p")
+	b.positions[key] = id
+	b.data.Points = append(b.data.Points, gisdata.GISPoint{Id: id, Coordinates: position})
+
+	return id
+}
+
+// uniqueId returns id when it is free, or generates one with the given prefix.
+func (b *builder) uniqueId(id, prefix string) string {
+	if id != "" && !b.used[id] {
+		b.used[id] = true
+		return id
+	}
+
+	for {
+		b.generated++
+		candidate := prefix + strconv.Itoa(b.generated)
+		if !b.used[candidate] {
+			b.used[candidate] = true
+			return candidate
+		}
+	}
+}
+
+// featureId reads the feature id, falling back to the id property.
+func featureId(f Feature) string {
+	for _, v := range []any{f.ID, f.Properties["id"]} {
+		switch id := v.(type) {
+		case string:
+			if id != "" {
+				return id
+			}
+		case float64:
+			return strconv.FormatFloat(id, 'f', -1, 64)
+		case int:
+			return strconv.Itoa(id)
+		}
+	}
+
+	return ""
+}
+
+func partId(id string, i, n int) string {
+	if id == "" || n == 1 {
+		return id
+	}
+
+	return fmt.Sprintf("%s-%d", id, i+1)
+}
+
+func normalize(position []float64) ([]float64, error) {
+	switch len(position) {
+	case 2:
+		return []float64{position[0], position[1], 0}, nil
+	case 3:
+		return position, nil
+	default:
+		return nil, ErrInvalidPosition
+	}
+}
+
+func positionKey(position []float64) string {
+	return fmt.Sprint(position)
+}
@@ -16,6 +16,9 @@ var (
 	ErrAPIResponseIsNil   = errors.New("API response is nil")
 	ErrMissingDataField   = errors.New("response is missing the 'Data' field")
 	ErrInvalidUUID        = errors.New("invalid network UUID")
+	ErrCircuitOpen        = errors.New("GIS backend is unavailable, calls are suspended")
+	ErrNetworkNotFound    = errors.New("network not found")
+	ErrProfileNotFound    = errors.New("profile not found")
 )
 
 // APIError represents an error response from the API
@@ -46,3 +49,11 @@ func ErrorDecodingBody(err error) error {
 func ErrorAPIResponseFailure(c ResponseCode) error {
 	return fmt.Errorf("API returned failure status[%d]: %s", c, c.AsString())
 }
+
+func ErrorUnknownSource(kind string) error {
+	return fmt.Errorf("unknown network source kind: %q", kind)
+}
+
+func ErrorUnknownProfile(name string) error {
+	return fmt.Errorf("%w: %q", ErrProfileNotFound, name)
+}
@@ -3,6 +3,19 @@
 // Author: teocci@yandex.com on 2025-3월-06
 package gisapi
 
+import "time"
+
+// NetworkInfo describes a network of a GIS backend. BBox is the
+// [minX, minY, minZ, maxX, maxY, maxZ] bounds of its geometry, empty when it has none.
+type NetworkInfo struct {
+	UUID      string    `json:"uuid"`
+	Name      string    `json:"name"`
+	NodeCount int       `json:"nodeCount"`
+	LinkCount int       `json:"linkCount"`
+	BBox      []float64 `json:"bbox,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
 type NodeGeometry struct {
 	ID       int       `json:"id"`
 	Guid     string    `json:"guid"`
@@ -30,3 +43,11 @@ type NodeListResponse struct {
 type LinkListResponse struct {
 	APIResponse[LinksData]
 }
+
+type NetworkInfoResponse struct {
+	APIResponse[NetworkInfo]
+}
+
+type NetworkListResponse struct {
+	APIResponse[[]NetworkInfo]
+}
@@ -4,6 +4,7 @@
 package gisapi
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 )
@@ -41,10 +42,10 @@ func (ar *APIResponse[T]) decode(r *http.Response) error {
 	return nil
 }
 
-// fetchGet performs an HTTP GET request to the given URL and decodes the response
+// fetch performs an HTTP GET request to the given URL and decodes the response
 // into the APIResponse. It uses the helper apiGetRequest (which you can adjust as needed).
-func (ar *APIResponse[T]) fetch(url string) error {
-	res, err := apiGetRequest[APIResponse[T]](url)
+func (ar *APIResponse[T]) fetch(ctx context.Context, url string) error {
+	res, err := apiGetRequest[APIResponse[T]](ctx, url)
 	if err != nil {
 		return err
 	}
@@ -56,8 +57,8 @@ func (ar *APIResponse[T]) fetch(url string) error {
 
 // fetchPost performs an HTTP POST request with the given payload to the given URL and decodes the response
 // into the APIResponse. It uses the helper apiPostRequest (which you can adjust as needed).
-func (ar *APIResponse[T]) fetchPost(url string, payload any) error {
-	res, err := apiPostRequest[APIResponse[T], any](url, &payload)
+func (ar *APIResponse[T]) fetchPost(ctx context.Context, url string, payload any) error {
+	res, err := apiPostRequest[APIResponse[T], any](ctx, url, &payload)
 	if err != nil {
 		return err
 	}
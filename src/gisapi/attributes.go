@@ -0,0 +1,139 @@
+// Package gisapi
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-14
+package gisapi
+
+const (
+	formatNodeAttributes = "%s/network/node-attributes"
+	formatLinkAttributes = "%s/network/link-attributes"
+)
+
+// FeatureRefs selects nodes or links by id or by guid; a feature matching either is selected.
+type FeatureRefs struct {
+	IDs   []int    `json:"ids,omitempty"`
+	Guids []string `json:"guids,omitempty"`
+}
+
+// AttributesRequest asks for the attributes of the selected features of a network.
+type AttributesRequest struct {
+	GeometryListRequest
+	FeatureRefs
+}
+
+// NodeAttributes holds the attributes of a node. Extra keeps the attributes of the backend
+// that have no field of their own.
+type NodeAttributes struct {
+	ID          int            `json:"id"`
+	Guid        string         `json:"guid"`
+	Type        int            `json:"type"`
+	Name        string         `json:"name,omitempty"`
+	Elevation   *float64       `json:"elevation,omitempty"`
+	InstallDate string         `json:"installDate,omitempty"`
+	Owner       string         `json:"owner,omitempty"`
+	Extra       map[string]any `json:"extra,omitempty"`
+}
+
+// LinkAttributes holds the attributes of a link. Diameter is in millimetres and Length in
+// the units of the geometry. Extra keeps the attributes that have no field of their own.
+type LinkAttributes struct {
+	ID          int            `json:"id"`
+	Guid        string         `json:"guid"`
+	Type        int            `json:"type"`
+	Material    string         `json:"material,omitempty"`
+	Diameter    *float64       `json:"diameter,omitempty"`
+	Length      *float64       `json:"length,omitempty"`
+	InstallDate string         `json:"installDate,omitempty"`
+	Owner       string         `json:"owner,omitempty"`
+	Extra       map[string]any `json:"extra,omitempty"`
+}
+
+type NodeAttributesResponse struct {
+	APIResponse[[]NodeAttributes]
+}
+
+type LinkAttributesResponse struct {
+	APIResponse[[]LinkAttributes]
+}
+
+// Len returns the number of references.
+func (r FeatureRefs) Len() int {
+	return len(r.IDs) + len(r.Guids)
+}
+
+// Matcher returns a function reporting whether a feature is selected.
+func (r FeatureRefs) Matcher() func(id int, guid string) bool {
+	ids := make(map[int]bool, len(r.IDs))
+	for _, id := range r.IDs {
+		ids[id] = true
+	}
+	guids := make(map[string]bool, len(r.Guids))
+	for _, guid := range r.Guids {
+		guids[guid] = true
+	}
+
+	return func(id int, guid string) bool {
+		return ids[id] || (guid != "" && guids[guid])
+	}
+}
+
+// Attributes returns the attributes known from the geometry of the node.
+func (n NodeGeometry) Attributes() NodeAttributes {
+	return NodeAttributes{ID: n.ID, Guid: n.Guid, Type: n.Type}
+}
+
+// Attributes returns the attributes known from the geometry of the link.
+func (l LinkGeometry) Attributes() LinkAttributes {
+	return LinkAttributes{ID: l.ID, Guid: l.Guid, Type: l.Type}
+}
+
+// feature is implemented by the node and link models, which are all identified by id and guid.
+type feature interface {
+	NodeGeometry | LinkGeometry | NodeAttributes | LinkAttributes
+}
+
+func featureRef[T feature](v T) (int, string) {
+	switch f := any(v).(type) {
+	case NodeGeometry:
+		return f.ID, f.Guid
+	case LinkGeometry:
+		return f.ID, f.Guid
+	case NodeAttributes:
+		return f.ID, f.Guid
+	case LinkAttributes:
+		return f.ID, f.Guid
+	}
+
+	return 0, ""
+}
+
+// Select returns the elements of the list selected by refs.
+func Select[T feature](list []T, refs FeatureRefs) []T {
+	match := refs.Matcher()
+
+	selected := []T{}
+	for _, v := range list {
+		if match(featureRef(v)) {
+			selected = append(selected, v)
+		}
+	}
+
+	return selected
+}
+
+// selectStream reads a stream, keeping the elements selected by refs, and closes it.
+func selectStream[T feature](stream *Stream[T], refs FeatureRefs) ([]T, error) {
+	defer stream.Close()
+
+	match := refs.Matcher()
+
+	selected := []T{}
+	var v T
+	for stream.Next(&v) {
+		if match(featureRef(v)) {
+			selected = append(selected, v)
+		}
+		v = *new(T)
+	}
+
+	return selected, stream.Err()
+}
@@ -0,0 +1,57 @@
+// Package gisapi
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-13
+package gisapi
+
+import "math"
+
+// Bounds accumulates the bounding box of network geometry.
+type Bounds struct {
+	min, max [3]float64
+	empty    bool
+}
+
+// NewBounds returns empty bounds.
+func NewBounds() *Bounds {
+	inf := math.Inf(1)
+
+	return &Bounds{min: [3]float64{inf, inf, inf}, max: [3]float64{-inf, -inf, -inf}, empty: true}
+}
+
+// Add extends the bounds to a position; a missing z counts as 0.
+func (b *Bounds) Add(p []float64) {
+	if len(p) < 2 {
+		return
+	}
+
+	for i := 0; i < 3; i++ {
+		v := 0.0
+		if i < len(p) {
+			v = p[i]
+		}
+		b.min[i] = min(b.min[i], v)
+		b.max[i] = max(b.max[i], v)
+	}
+	b.empty = false
+}
+
+// AddNode extends the bounds to a node.
+func (b *Bounds) AddNode(n NodeGeometry) {
+	b.Add(n.Geometry)
+}
+
+// AddLink extends the bounds to the polyline of a link.
+func (b *Bounds) AddLink(l LinkGeometry) {
+	for _, p := range l.Geometry {
+		b.Add(p)
+	}
+}
+
+// BBox returns [minX, minY, minZ, maxX, maxY, maxZ], or nil when nothing was added.
+func (b *Bounds) BBox() []float64 {
+	if b.empty {
+		return nil
+	}
+
+	return []float64{b.min[0], b.min[1], b.min[2], b.max[0], b.max[1], b.max[2]}
+}
@@ -5,6 +5,7 @@ package gisapi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -77,19 +78,39 @@ func createRequest(method, url string, headers map[string]string, payload any) (
 	return req, nil
 }
 
-// requester sends an HTTP request using the given method, URL, payload, and extra headers.
-// It automatically adds the default ApiKey and Content-Type headers.
-func requester(method, url string, headers map[string]string, payload any) (*http.Response, error) {
-	req, err := createRequest(method, url, headers, payload)
+// requester sends an HTTP request using the given method, URL, payload, and extra headers
+// through the shared client of the profile. Idempotent requests are retried on failure.
+func requester(ctx context.Context, method, url string, headers map[string]string, payload any, idempotent bool) (*http.Response, error) {
+	return client.do(ctx, idempotent, func() (*http.Request, error) {
+		return createRequest(method, url, headers, payload)
+	})
+}
+
+// query sends a read-only POST with the API key through the client; it is retried like a GET.
+func (cl *Client) query(ctx context.Context, url string, payload any) (*http.Response, error) {
+	headers, err := initAuthHeaders()
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.do(ctx, true, func() (*http.Request, error) {
+		return createRequest("POST", url, headers, payload)
+	})
+}
+
+// get sends a GET with the API key through the client.
+func (cl *Client) get(ctx context.Context, url string) (*http.Response, error) {
+	headers, err := initAuthHeaders()
 	if err != nil {
 		return nil, err
 	}
 
-	client := &http.Client{}
-	return client.Do(req)
+	return cl.do(ctx, true, func() (*http.Request, error) {
+		return createRequest("GET", url, headers, nil)
+	})
 }
 
-func apiRequestWithHeaders[T any, P any](method, url string, extraHeader map[string]string, payload *P) (*T, error) {
+func apiRequestWithHeaders[T any, P any](ctx context.Context, method, url string, extraHeader map[string]string, payload *P, idempotent bool) (*T, error) {
 	headers, err := initAuthHeaders()
 	if err != nil {
 		return nil, err
@@ -101,7 +122,7 @@ func apiRequestWithHeaders[T any, P any](method, url string, extraHeader map[str
 		}
 	}
 
-	resp, err := requester(method, url, headers, payload)
+	resp, err := requester(ctx, method, url, headers, payload, idempotent)
 	if err != nil {
 		return nil, err
 	}
@@ -117,17 +138,17 @@ func apiRequestWithHeaders[T any, P any](method, url string, extraHeader map[str
 
 // apiRequest is a generic function that sends an HTTP request with the given method and payload,
 // and decodes the JSON response into a newly allocated variable of type T.
-func apiRequest[T any, P any](method, url string, payload *P) (*T, error) {
-	return apiRequestWithHeaders[T](method, url, nil, payload)
+func apiRequest[T any, P any](ctx context.Context, method, url string, payload *P, idempotent bool) (*T, error) {
+	return apiRequestWithHeaders[T](ctx, method, url, nil, payload, idempotent)
 }
 
 // apiGetRequest is a convenience function for GET requests using generics.
 // Since GET requests do not have a payload, we pass nil.
-func apiGetRequest[T any](url string) (*T, error) {
-	return apiRequest[T, any]("GET", url, nil)
+func apiGetRequest[T any](ctx context.Context, url string) (*T, error) {
+	return apiRequest[T, any](ctx, "GET", url, nil, true)
 }
 
 // apiPostRequest is a convenience function for POST requests using generics.
-func apiPostRequest[T any, P any](url string, payload *P) (*T, error) {
-	return apiRequest[T, P]("POST", url, payload)
+func apiPostRequest[T any, P any](ctx context.Context, url string, payload *P) (*T, error) {
+	return apiRequest[T, P](ctx, "POST", url, payload, false)
 }
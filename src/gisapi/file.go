@@ -0,0 +1,234 @@
+// Package gisapi
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-12
+package gisapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+const (
+	formatNetworkFile = "network-%s-%s.json"
+
+	fileKindNodes          = "nodes"
+	fileKindLinks          = "links"
+	fileKindNodeAttributes = "node-attributes"
+	fileKindLinkAttributes = "link-attributes"
+)
+
+// FileSource reads networks from JSON files laid out like web/json: the nodes and links of
+// a network are in network-<uuid>-nodes.json and network-<uuid>-links.json. The files hold
+// either an APIResponse or a bare array. A network needs one of the two files; the other
+// kind reads as empty. Networks without files of their own are served from the fallback
+// files, when set. Attributes are read from network-<uuid>-node-attributes.json
+// and network-<uuid>-link-attributes.json, or derived from the geometry without them.
+type FileSource struct {
+	dir      string
+	fallback string
+}
+
+// NewFileSource creates a source for the files in dir.
+func NewFileSource(dir, fallback string) *FileSource {
+	return &FileSource{dir: dir, fallback: fallback}
+}
+
+// Nodes opens the nodes file of a network.
+func (s *FileSource) Nodes(_ context.Context, uuid string) (*Stream[NodeGeometry], error) {
+	return fileStream[NodeGeometry](s, uuid, fileKindNodes)
+}
+
+// Links opens the links file of a network.
+func (s *FileSource) Links(_ context.Context, uuid string) (*Stream[LinkGeometry], error) {
+	return fileStream[LinkGeometry](s, uuid, fileKindLinks)
+}
+
+// Networks describes every network with a nodes or links file. It reads all the files, so it
+// is slow for large networks and callers should keep the result.
+func (s *FileSource) Networks(ctx context.Context) ([]NetworkInfo, error) {
+	var names []string
+	for _, kind := range []string{fileKindNodes, fileKindLinks} {
+		paths, err := filepath.Glob(filepath.Join(s.dir, fmt.Sprintf(formatNetworkFile, "*", kind)))
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range paths {
+			name := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), "network-"), "-"+kind+".json")
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	list := make([]NetworkInfo, 0, len(names))
+	for _, name := range names {
+		info, err := s.describe(ctx, name, name)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, info)
+	}
+
+	return list, nil
+}
+
+// Network describes a network by reading its files, so it is as slow as reading them.
+func (s *FileSource) Network(ctx context.Context, uuid string) (NetworkInfo, error) {
+	name, err := s.name(uuid)
+	if err != nil {
+		return NetworkInfo{}, err
+	}
+
+	return s.describe(ctx, uuid, name)
+}
+
+func (s *FileSource) describe(ctx context.Context, uuid, name string) (NetworkInfo, error) {
+	info := NetworkInfo{UUID: uuid, Name: name}
+	bounds := NewBounds()
+
+	nodes, err := fileStream[NodeGeometry](s, name, fileKindNodes)
+	if err != nil {
+		return NetworkInfo{}, err
+	}
+	info.NodeCount, err = scan(nodes, bounds.AddNode)
+	if err != nil {
+		return NetworkInfo{}, err
+	}
+	if err := ctx.Err(); err != nil {
+		return NetworkInfo{}, err
+	}
+
+	links, err := fileStream[LinkGeometry](s, name, fileKindLinks)
+	if err != nil {
+		return NetworkInfo{}, err
+	}
+	info.LinkCount, err = scan(links, bounds.AddLink)
+	if err != nil {
+		return NetworkInfo{}, err
+	}
+	info.BBox = bounds.BBox()
+
+	for _, kind := range []string{fileKindNodes, fileKindLinks} {
+		stat, err := os.Stat(s.path(name, kind))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return NetworkInfo{}, err
+		}
+		if stat.ModTime().After(info.UpdatedAt) {
+			info.UpdatedAt = stat.ModTime()
+		}
+	}
+
+	return info, nil
+}
+
+// NodeAttributes scans the files of a network for the selected nodes.
+func (s *FileSource) NodeAttributes(_ context.Context, uuid string, refs FeatureRefs) ([]NodeAttributes, error) {
+	return fileAttributes(s, uuid, fileKindNodeAttributes, fileKindNodes, refs, NodeGeometry.Attributes)
+}
+
+// LinkAttributes scans the files of a network for the selected links.
+func (s *FileSource) LinkAttributes(_ context.Context, uuid string, refs FeatureRefs) ([]LinkAttributes, error) {
+	return fileAttributes(s, uuid, fileKindLinkAttributes, fileKindLinks, refs, LinkGeometry.Attributes)
+}
+
+func fileAttributes[A, G feature](s *FileSource, uuid, attributesKind, geometryKind string, refs FeatureRefs, derive func(G) A) ([]A, error) {
+	name, err := s.name(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(s.path(name, attributesKind)); err == nil {
+		stream, err := fileStream[A](s, name, attributesKind)
+		if err != nil {
+			return nil, err
+		}
+		return selectStream(stream, refs)
+	}
+
+	stream, err := fileStream[G](s, name, geometryKind)
+	if err != nil {
+		return nil, err
+	}
+	selected, err := selectStream(stream, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]A, len(selected))
+	for i, g := range selected {
+		list[i] = derive(g)
+	}
+
+	return list, nil
+}
+
+// name returns the name in the files of a network: its uuid, or the fallback.
+func (s *FileSource) name(uuid string) (string, error) {
+	if uuid == "" || filepath.Base(uuid) != uuid || uuid == ".." {
+		return "", ErrInvalidUUID
+	}
+
+	for _, name := range []string{uuid, s.fallback} {
+		if name == "" {
+			continue
+		}
+		for _, kind := range []string{fileKindNodes, fileKindLinks} {
+			if _, err := os.Stat(s.path(name, kind)); err == nil {
+				return name, nil
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return "", err
+			}
+		}
+	}
+
+	return "", ErrNetworkNotFound
+}
+
+func (s *FileSource) path(name, kind string) string {
+	return filepath.Join(s.dir, fmt.Sprintf(formatNetworkFile, name, kind))
+}
+
+// scan passes every element of a stream to fn, closes it and returns the element count.
+func scan[T any](stream *Stream[T], fn func(T)) (int, error) {
+	defer stream.Close()
+
+	count := 0
+	var v T
+	for stream.Next(&v) {
+		fn(v)
+		count++
+		v = *new(T)
+	}
+
+	return count, stream.Err()
+}
+
+func fileStream[T any](s *FileSource, uuid, kind string) (*Stream[T], error) {
+	name, err := s.name(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(s.path(name, kind))
+	if errors.Is(err, fs.ErrNotExist) && (kind == fileKindNodes || kind == fileKindLinks) {
+		// The network has geometry of the other kind only.
+		return OpenStream[T](io.NopCloser(strings.NewReader("[]")))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return OpenStream[T](f)
+}
@@ -0,0 +1,90 @@
+// Package gisapi
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-12
+package gisapi
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileSource(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"network-dummy-nodes.json":           `{"responseCode":1000,"data":[{"id":1,"geometry":[1,2,3]},{"id":2},{"id":3}]}`,
+		"network-dummy-links.json":           `[{"id":9,"startNodeId":1,"endNodeId":2}]`,
+		"network-dummy-link-attributes.json": `[{"id":9,"guid":"L9","material":"PE","diameter":150}]`,
+	}
+	for name, body := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx := context.Background()
+
+	if _, err := NewFileSource(dir, "").Nodes(ctx, "abc"); !errors.Is(err, ErrNetworkNotFound) {
+		t.Errorf("Expected ErrNetworkNotFound without a fallback, got %v", err)
+	}
+	if _, err := NewFileSource(dir, "dummy").Nodes(ctx, "../dummy"); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("Expected ErrInvalidUUID for a path, got %v", err)
+	}
+
+	source := NewFileSource(dir, "dummy")
+	nodes, err := Collect(source.Nodes(ctx, "abc"))
+	if err != nil || len(nodes) != 3 {
+		t.Fatalf("Expected the fallback nodes, got %v, %v", nodes, err)
+	}
+	links, err := Collect(source.Links(ctx, "abc"))
+	if err != nil || len(links) != 1 || links[0].EndNodeId != 2 {
+		t.Fatalf("Expected the fallback links, got %v, %v", links, err)
+	}
+
+	info, err := source.Network(ctx, "abc")
+	if err != nil || info.Name != "dummy" || info.NodeCount != 3 || info.LinkCount != 1 || info.UpdatedAt.IsZero() {
+		t.Fatalf("Unexpected info %+v, %v", info, err)
+	}
+	if len(info.BBox) != 6 || info.BBox[0] != 1 || info.BBox[5] != 3 {
+		t.Errorf("Unexpected bbox %v", info.BBox)
+	}
+
+	attrs, err := source.NodeAttributes(ctx, "abc", FeatureRefs{IDs: []int{2, 3}})
+	if err != nil || len(attrs) != 2 || attrs[0].ID != 2 {
+		t.Fatalf("Expected the attributes of nodes 2 and 3, got %v, %v", attrs, err)
+	}
+
+	linkAttrs, err := source.LinkAttributes(ctx, "abc", FeatureRefs{Guids: []string{"L9"}})
+	if err != nil || len(linkAttrs) != 1 || linkAttrs[0].Material != "PE" || *linkAttrs[0].Diameter != 150 {
+		t.Fatalf("Expected the attributes file of link 9, got %v, %v", linkAttrs, err)
+	}
+
+	list, err := source.Networks(ctx)
+	if err != nil || len(list) != 1 || list[0].UUID != "dummy" {
+		t.Fatalf("Expected the dummy network, got %v, %v", list, err)
+	}
+}
+
+func TestFileSourceLinksOnly(t *testing.T) {
+	dir := t.TempDir()
+	body := `[{"id":9,"startNodeId":1,"endNodeId":2,"geometry":[[0,0,0],[1,1,1]]}]`
+	if err := os.WriteFile(filepath.Join(dir, "network-dummy-links.json"), []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	source := NewFileSource(dir, "dummy")
+
+	nodes, err := Collect(source.Nodes(ctx, "abc"))
+	if err != nil || len(nodes) != 0 {
+		t.Fatalf("Expected no nodes, got %v, %v", nodes, err)
+	}
+	if attrs, err := source.LinkAttributes(ctx, "abc", FeatureRefs{IDs: []int{9}}); err != nil || len(attrs) != 1 {
+		t.Fatalf("Expected the attributes of link 9, got %v, %v", attrs, err)
+	}
+
+	list, err := source.Networks(ctx)
+	if err != nil || len(list) != 1 || list[0].LinkCount != 1 || list[0].NodeCount != 0 {
+		t.Fatalf("Expected the links-only network, got %+v, %v", list, err)
+	}
+}
@@ -5,6 +5,7 @@ package gisapi
 
 import (
 	"fmt"
+	"log"
 
 	"github.com/teocci/go-hynix-3d-viewer/src/config"
 )
@@ -17,7 +18,7 @@ const (
 
 var (
 	profile *config.ProfileData
-	apiURL  string
+	client  = NewClient(config.ClientSetup{})
 )
 
 func InitVars(config *config.ProfileData) {
@@ -29,11 +30,21 @@ func InitVars(config *config.ProfileData) {
 }
 
 func InitAPIConfig() {
-	apiProtocol := profile.API.Protocol
-	apiHost := profile.API.Host
-	apiPort := profile.API.Port
-	apiBaseURL := baseAPIURL(apiProtocol, apiHost, apiPort)
-	apiURL = fmt.Sprintf(formatAPI, apiBaseURL)
+	client = NewClient(profile.API.Client)
+
+	s, err := newSource(profile, client)
+	if err != nil {
+		log.Fatalf("Invalid network source: %v", err)
+	}
+	SetSource(s)
+	resetProfileSources()
+}
+
+// profileAPIURL returns the base URL of the v2 API of a profile.
+func profileAPIURL(profile *config.ProfileData) string {
+	apiBaseURL := baseAPIURL(profile.API.Protocol, profile.API.Host, profile.API.Port)
+
+	return fmt.Sprintf(formatAPI, apiBaseURL)
 }
 
 func baseAPIAddress(host string, port int) string {
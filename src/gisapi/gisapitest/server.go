@@ -0,0 +1,307 @@
+// Package gisapitest
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-12
+package gisapitest
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+)
+
+const (
+	headerAPIKey = "ApiKey"
+	apiPrefix    = "/api/v2"
+)
+
+// Server is a fake GIS backend speaking the v2 REST protocol: it answers the geometry and
+// info queries of gisapi with APIResponse envelopes and ResponseCode failures. It serves the
+// networks of any source, so a gisapi.FileSource over web/json makes an offline backend.
+type Server struct {
+	*httptest.Server
+
+	// APIKey, when set before the first request, is required in the ApiKey header.
+	APIKey string
+
+	source   gisapi.NetworkSource
+	requests atomic.Int64
+
+	mutex      sync.Mutex
+	failures   int
+	failStatus int
+}
+
+// NewServer starts a server for the networks of source. Close it when done.
+func NewServer(source gisapi.NetworkSource) *Server {
+	s := &Server{source: source}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(apiPrefix+"/network/node-geometry", s.post(func(w http.ResponseWriter, r *http.Request, req gisapi.AttributesRequest) {
+		writeStream(w, r, req.GeometryListRequest, s.source.Nodes)
+	}))
+	mux.HandleFunc(apiPrefix+"/network/link-geometry", s.post(func(w http.ResponseWriter, r *http.Request, req gisapi.AttributesRequest) {
+		writeStream(w, r, req.GeometryListRequest, s.source.Links)
+	}))
+	mux.HandleFunc(apiPrefix+"/network/node-attributes", s.post(func(w http.ResponseWriter, r *http.Request, req gisapi.AttributesRequest) {
+		list, err := s.source.NodeAttributes(r.Context(), req.UUID, req.FeatureRefs)
+		writeList(w, req.UUID, list, err)
+	}))
+	mux.HandleFunc(apiPrefix+"/network/link-attributes", s.post(func(w http.ResponseWriter, r *http.Request, req gisapi.AttributesRequest) {
+		list, err := s.source.LinkAttributes(r.Context(), req.UUID, req.FeatureRefs)
+		writeList(w, req.UUID, list, err)
+	}))
+	mux.HandleFunc(apiPrefix+"/network/info", s.post(s.info))
+	mux.HandleFunc(apiPrefix+"/network/list", s.list)
+	mux.HandleFunc(apiPrefix+"/network/types", s.types)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, r, http.StatusNotFound, "Not Found")
+	})
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+// APIURL returns the base URL to give gisapi.NewRESTSource.
+func (s *Server) APIURL() string {
+	return s.URL + apiPrefix
+}
+
+// FailNext makes the next count requests answer status, to exercise retries and the breaker.
+func (s *Server) FailNext(count, status int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.failures, s.failStatus = count, status
+}
+
+// Requests returns the number of requests received.
+func (s *Server) Requests() int {
+	return int(s.requests.Load())
+}
+
+func (s *Server) post(handle func(http.ResponseWriter, *http.Request, gisapi.AttributesRequest)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.accept(w, r, http.MethodPost) {
+			return
+		}
+
+		var req gisapi.AttributesRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeFailure(w, req.UUID, gisapi.ResponseCodeRequestJSONError)
+			return
+		}
+		if !s.authorized(r) {
+			writeFailure(w, req.UUID, gisapi.ResponseCodeApiKeyError)
+			return
+		}
+
+		handle(w, r, req)
+	}
+}
+
+// accept counts the request and answers the injected failures and wrong methods.
+func (s *Server) accept(w http.ResponseWriter, r *http.Request, method string) bool {
+	s.requests.Add(1)
+
+	if status := s.failure(); status != 0 {
+		writeError(w, r, status, http.StatusText(status))
+		return false
+	}
+	if r.Method != method {
+		writeError(w, r, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+		return false
+	}
+
+	return true
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	return s.APIKey == "" || r.Header.Get(headerAPIKey) == s.APIKey
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request) {
+	if !s.accept(w, r, http.MethodGet) {
+		return
+	}
+	if !s.authorized(r) {
+		writeFailure(w, "", gisapi.ResponseCodeApiKeyError)
+		return
+	}
+
+	list, err := s.source.Networks(r.Context())
+	if err != nil {
+		writeFailure(w, "", failureCode(err))
+		return
+	}
+
+	writeJSON(w, gisapi.NetworkListResponse{APIResponse: gisapi.APIResponse[[]gisapi.NetworkInfo]{
+		ResponseCode:    gisapi.ResponseCodeSuccess,
+		ResponseMessage: gisapi.ResponseCodeSuccess.AsString(),
+		Data:            &list,
+	}})
+}
+
+func (s *Server) failure() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.failures == 0 {
+		return 0
+	}
+	s.failures--
+
+	return s.failStatus
+}
+
+func (s *Server) info(w http.ResponseWriter, r *http.Request, req gisapi.AttributesRequest) {
+	info, err := s.source.Network(r.Context(), req.UUID)
+	if err != nil {
+		writeFailure(w, req.UUID, failureCode(err))
+		return
+	}
+
+	writeJSON(w, gisapi.NetworkInfoResponse{APIResponse: gisapi.APIResponse[gisapi.NetworkInfo]{
+		RequestId:       req.UUID,
+		ResponseCode:    gisapi.ResponseCodeSuccess,
+		ResponseMessage: gisapi.ResponseCodeSuccess.AsString(),
+		Data:            &info,
+	}})
+}
+
+func (s *Server) types(w http.ResponseWriter, r *http.Request) {
+	if !s.accept(w, r, http.MethodGet) {
+		return
+	}
+	if !s.authorized(r) {
+		writeFailure(w, "", gisapi.ResponseCodeApiKeyError)
+		return
+	}
+
+	source, ok := s.source.(gisapi.TypeSource)
+	if !ok {
+		writeError(w, r, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	types, err := source.Types(r.Context())
+	if err != nil {
+		writeFailure(w, "", failureCode(err))
+		return
+	}
+
+	writeJSON(w, gisapi.ElementTypesResponse{APIResponse: gisapi.APIResponse[gisapi.ElementTypes]{
+		ResponseCode:    gisapi.ResponseCodeSuccess,
+		ResponseMessage: gisapi.ResponseCodeSuccess.AsString(),
+		Data:            &types,
+	}})
+}
+
+// writeStream copies a stream of the source element by element, like the backend does for
+// large networks. An unknown network is an empty result, as for an SQL query.
+func writeStream[T any](
+	w http.ResponseWriter,
+	r *http.Request,
+	req gisapi.GeometryListRequest,
+	open func(context.Context, string) (*gisapi.Stream[T], error),
+) {
+	stream, err := open(r.Context(), req.UUID)
+	if errors.Is(err, gisapi.ErrNetworkNotFound) {
+		writeJSON(w, gisapi.APIResponse[[]T]{
+			RequestId:       req.UUID,
+			ResponseCode:    gisapi.ResponseCodeSuccess,
+			ResponseMessage: gisapi.ResponseCodeSuccess.AsString(),
+			Data:            &[]T{},
+		})
+		return
+	}
+	if err != nil {
+		writeFailure(w, req.UUID, failureCode(err))
+		return
+	}
+	defer stream.Close()
+
+	w.Header().Set("Content-Type", "application/json")
+	bw := bufio.NewWriter(w)
+	head, _ := json.Marshal(map[string]any{
+		"requestId":       req.UUID,
+		"responseCode":    gisapi.ResponseCodeSuccess,
+		"responseMessage": gisapi.ResponseCodeSuccess.AsString(),
+	})
+	_, _ = bw.Write(head[:len(head)-1])
+	_, _ = bw.WriteString(`,"data":[`)
+
+	encoder := json.NewEncoder(bw)
+	var v T
+	for i := 0; stream.Next(&v); i++ {
+		if i > 0 {
+			_ = bw.WriteByte(',')
+		}
+		_ = encoder.Encode(v)
+		v = *new(T)
+	}
+	if stream.Err() != nil {
+		// The status line is gone: drop the connection so the client sees a truncated body.
+		panic(http.ErrAbortHandler)
+	}
+
+	_, _ = bw.WriteString("]}")
+	_ = bw.Flush()
+}
+
+// writeList answers a list, which is empty for an unknown network.
+func writeList[T any](w http.ResponseWriter, requestId string, list []T, err error) {
+	if err != nil && !errors.Is(err, gisapi.ErrNetworkNotFound) {
+		writeFailure(w, requestId, failureCode(err))
+		return
+	}
+	if list == nil {
+		list = []T{}
+	}
+
+	writeJSON(w, gisapi.APIResponse[[]T]{
+		RequestId:       requestId,
+		ResponseCode:    gisapi.ResponseCodeSuccess,
+		ResponseMessage: gisapi.ResponseCodeSuccess.AsString(),
+		Data:            &list,
+	})
+}
+
+func failureCode(err error) gisapi.ResponseCode {
+	if errors.Is(err, gisapi.ErrNetworkNotFound) {
+		return gisapi.ResponseCodeUndefined
+	}
+
+	return gisapi.ResponseCodeInternalError
+}
+
+func writeFailure(w http.ResponseWriter, requestId string, code gisapi.ResponseCode) {
+	writeJSON(w, gisapi.APIResponse[any]{
+		RequestId:       requestId,
+		ResponseCode:    code,
+		ResponseMessage: code.AsString(),
+	})
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(gisapi.APIError{
+		Timestamp: time.Now(),
+		Status:    status,
+		Error:     message,
+		Path:      r.URL.Path,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
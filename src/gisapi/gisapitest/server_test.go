@@ -0,0 +1,128 @@
+// Package gisapitest
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-12
+package gisapitest
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/config"
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+)
+
+const testUUID = "0b7e3c1e-8a52-4c1f-9d0e-2f6a1c3b5d47"
+
+func testServer(t *testing.T) (*Server, *gisapi.RESTSource) {
+	t.Setenv("API_KEY", "secret")
+
+	source := NewSource(Network{
+		Info: gisapi.NetworkInfo{UUID: testUUID, Name: "test"},
+		Nodes: gisapi.NodesData{
+			{ID: 1, Type: 3, Geometry: []float64{0, 0, 0}},
+			{ID: 2, Type: 3, Geometry: []float64{10, 0, 0}},
+		},
+		Links: gisapi.LinksData{
+			{ID: 5, Guid: "L5", StartNodeId: 1, EndNodeId: 2, Geometry: [][]float64{{0, 0, 0}, {10, 0, 0}}},
+		},
+		LinkAttributes: []gisapi.LinkAttributes{
+			{ID: 5, Guid: "L5", Material: "DCIP", Owner: "water works"},
+		},
+	})
+	source.ElementTypes = gisapi.ElementTypes{Nodes: []gisapi.ElementType{{Code: 3, Name: "Junction"}}}
+
+	server := NewServer(source)
+	server.APIKey = "secret"
+	t.Cleanup(server.Close)
+
+	cl := gisapi.NewClient(config.ClientSetup{Retries: 2, RetryBackoff: time.Millisecond})
+
+	return server, gisapi.NewRESTSource(server.APIURL(), cl)
+}
+
+func TestRESTSourceAgainstServer(t *testing.T) {
+	_, source := testServer(t)
+	ctx := context.Background()
+
+	nodes, err := gisapi.Collect(source.Nodes(ctx, testUUID))
+	if err != nil || len(nodes) != 2 || nodes[1].ID != 2 {
+		t.Fatalf("Expected two nodes, got %v, %v", nodes, err)
+	}
+
+	links, err := gisapi.Collect(source.Links(ctx, testUUID))
+	if err != nil || len(links) != 1 || len(links[0].Geometry) != 2 {
+		t.Fatalf("Expected one link, got %v, %v", links, err)
+	}
+
+	info, err := source.Network(ctx, testUUID)
+	if err != nil || info.Name != "test" || info.NodeCount != 2 || info.LinkCount != 1 {
+		t.Fatalf("Unexpected info %+v, %v", info, err)
+	}
+
+	list, err := source.Networks(ctx)
+	if err != nil || len(list) != 1 || list[0].UUID != testUUID {
+		t.Fatalf("Expected the test network, got %v, %v", list, err)
+	}
+	if bbox := list[0].BBox; len(bbox) != 6 || bbox[0] != 0 || bbox[3] != 10 {
+		t.Errorf("Unexpected bbox %v", bbox)
+	}
+
+	types, err := source.Types(ctx)
+	if err != nil || len(types.Nodes) != 1 || types.Nodes[0].Name != "Junction" {
+		t.Fatalf("Expected the junction type, got %+v, %v", types, err)
+	}
+
+	empty, err := gisapi.Collect(source.Nodes(ctx, "unknown"))
+	if err != nil || len(empty) != 0 {
+		t.Fatalf("Expected no nodes for an unknown network, got %v, %v", empty, err)
+	}
+	if _, err := source.Network(ctx, "unknown"); err == nil {
+		t.Fatal("Expected a failure code for an unknown network")
+	}
+}
+
+func TestServerFailuresAreRetried(t *testing.T) {
+	server, source := testServer(t)
+
+	server.FailNext(2, http.StatusServiceUnavailable)
+	nodes, err := gisapi.Collect(source.Nodes(context.Background(), testUUID))
+	if err != nil || len(nodes) != 2 {
+		t.Fatalf("Expected the third attempt to succeed, got %v, %v", nodes, err)
+	}
+	if server.Requests() != 3 {
+		t.Errorf("Expected 3 requests, got %d", server.Requests())
+	}
+
+	server.FailNext(1, http.StatusNotFound)
+	if _, err := source.Nodes(context.Background(), testUUID); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Expected the decoded API error, got %v", err)
+	}
+}
+
+func TestServerRequiresAPIKey(t *testing.T) {
+	_, source := testServer(t)
+	t.Setenv("API_KEY", "wrong")
+
+	_, err := source.Links(context.Background(), testUUID)
+	if err == nil || !strings.Contains(err.Error(), gisapi.ResponseCodeApiKeyError.AsString()) {
+		t.Fatalf("Expected an ApiKey error, got %v", err)
+	}
+}
+
+func TestAttributesLookup(t *testing.T) {
+	_, source := testServer(t)
+	ctx := context.Background()
+
+	nodes, err := source.NodeAttributes(ctx, testUUID, gisapi.FeatureRefs{IDs: []int{2, 99}})
+	if err != nil || len(nodes) != 1 || nodes[0].ID != 2 || nodes[0].Type != 3 {
+		t.Fatalf("Expected the attributes of node 2 derived from its geometry, got %v, %v", nodes, err)
+	}
+
+	links, err := source.LinkAttributes(ctx, testUUID, gisapi.FeatureRefs{Guids: []string{"L5"}})
+	if err != nil || len(links) != 1 || links[0].Material != "DCIP" {
+		t.Fatalf("Expected the attributes of link L5, got %v, %v", links, err)
+	}
+}
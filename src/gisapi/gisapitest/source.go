@@ -0,0 +1,141 @@
+// Package gisapitest
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-12
+package gisapitest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+)
+
+// Network is a network held in memory by a Source. Features without attributes of their
+// own get the ones derived from their geometry.
+type Network struct {
+	Info           gisapi.NetworkInfo
+	Nodes          gisapi.NodesData
+	Links          gisapi.LinksData
+	NodeAttributes []gisapi.NodeAttributes
+	LinkAttributes []gisapi.LinkAttributes
+}
+
+// Source is a gisapi.NetworkSource over networks held in memory.
+type Source struct {
+	// ElementTypes are the node and link types served by Types.
+	ElementTypes gisapi.ElementTypes
+
+	networks map[string]Network
+	order    []string
+}
+
+// NewSource creates a source serving the networks by their Info.UUID. The node and link
+// counts and the bounds of their info are filled in.
+func NewSource(networks ...Network) *Source {
+	s := &Source{networks: map[string]Network{}}
+	for _, n := range networks {
+		bounds := gisapi.NewBounds()
+		for _, node := range n.Nodes {
+			bounds.AddNode(node)
+		}
+		for _, link := range n.Links {
+			bounds.AddLink(link)
+		}
+
+		n.Info.NodeCount = len(n.Nodes)
+		n.Info.LinkCount = len(n.Links)
+		n.Info.BBox = bounds.BBox()
+		s.networks[n.Info.UUID] = n
+		s.order = append(s.order, n.Info.UUID)
+	}
+
+	return s
+}
+
+// Networks returns the info of the networks in the order they were given.
+func (s *Source) Networks(_ context.Context) ([]gisapi.NetworkInfo, error) {
+	list := make([]gisapi.NetworkInfo, 0, len(s.order))
+	for _, uuid := range s.order {
+		list = append(list, s.networks[uuid].Info)
+	}
+
+	return list, nil
+}
+
+func (s *Source) Nodes(_ context.Context, uuid string) (*gisapi.Stream[gisapi.NodeGeometry], error) {
+	n, ok := s.networks[uuid]
+	if !ok {
+		return nil, gisapi.ErrNetworkNotFound
+	}
+
+	return memoryStream[gisapi.NodeGeometry](n.Nodes)
+}
+
+func (s *Source) Links(_ context.Context, uuid string) (*gisapi.Stream[gisapi.LinkGeometry], error) {
+	n, ok := s.networks[uuid]
+	if !ok {
+		return nil, gisapi.ErrNetworkNotFound
+	}
+
+	return memoryStream[gisapi.LinkGeometry](n.Links)
+}
+
+func (s *Source) Network(_ context.Context, uuid string) (gisapi.NetworkInfo, error) {
+	n, ok := s.networks[uuid]
+	if !ok {
+		return gisapi.NetworkInfo{}, gisapi.ErrNetworkNotFound
+	}
+
+	return n.Info, nil
+}
+
+func (s *Source) NodeAttributes(_ context.Context, uuid string, refs gisapi.FeatureRefs) ([]gisapi.NodeAttributes, error) {
+	n, ok := s.networks[uuid]
+	if !ok {
+		return nil, gisapi.ErrNetworkNotFound
+	}
+
+	list := n.NodeAttributes
+	if list == nil {
+		for _, node := range n.Nodes {
+			list = append(list, node.Attributes())
+		}
+	}
+
+	return gisapi.Select(list, refs), nil
+}
+
+func (s *Source) LinkAttributes(_ context.Context, uuid string, refs gisapi.FeatureRefs) ([]gisapi.LinkAttributes, error) {
+	n, ok := s.networks[uuid]
+	if !ok {
+		return nil, gisapi.ErrNetworkNotFound
+	}
+
+	list := n.LinkAttributes
+	if list == nil {
+		for _, link := range n.Links {
+			list = append(list, link.Attributes())
+		}
+	}
+
+	return gisapi.Select(list, refs), nil
+}
+
+func (s *Source) Types(_ context.Context) (gisapi.ElementTypes, error) {
+	return s.ElementTypes, nil
+}
+
+func memoryStream[T any](list []T) (*gisapi.Stream[T], error) {
+	if list == nil {
+		list = []T{}
+	}
+
+	data, err := json.Marshal(list)
+	if err != nil {
+		return nil, err
+	}
+
+	return gisapi.OpenStream[T](io.NopCloser(bytes.NewReader(data)))
+}
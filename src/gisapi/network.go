@@ -3,7 +3,7 @@
 // Author: teocci@yandex.com on 2025-3월-07
 package gisapi
 
-import "fmt"
+import "context"
 
 type GeometryListRequest struct {
 	UUID string `json:"requestId"`
@@ -12,52 +12,28 @@ type GeometryListRequest struct {
 const (
 	formatNetworkNode = "%s/network/node-geometry"
 	formatNetworkLink = "%s/network/link-geometry"
+	formatNetworkInfo = "%s/network/info"
+	formatNetworkList = "%s/network/list"
 )
 
-func (n *NodesData) ByNetworkUUID(uuid string) error {
-	if uuid == "" {
-		return ErrInvalidUUID
-	}
-
-	url := fmt.Sprintf(formatNetworkNode, apiURL)
-
-	fmt.Printf("URL: %s\n", url)
-
-	payload := GeometryListRequest{
-		UUID: uuid,
-	}
-
-	res := NodeListResponse{}
-	err := res.fetchPost(url, payload)
+func (n *NodesData) ByNetworkUUID(ctx context.Context, uuid string) error {
+	list, err := Collect(OpenNodes(ctx, uuid))
 	if err != nil {
 		return err
 	}
 
-	*n = *res.Data
+	*n = list
 
 	return nil
 }
 
-func (l *LinksData) ByNetworkUUID(uuid string) error {
-	if uuid == "" {
-		return ErrInvalidUUID
-	}
-
-	url := fmt.Sprintf(formatNetworkLink, apiURL)
-
-	fmt.Printf("URL: %s\n", url)
-
-	payload := GeometryListRequest{
-		UUID: uuid,
-	}
-
-	res := LinkListResponse{}
-	err := res.fetchPost(url, payload)
+func (l *LinksData) ByNetworkUUID(ctx context.Context, uuid string) error {
+	list, err := Collect(OpenLinks(ctx, uuid))
 	if err != nil {
 		return err
 	}
 
-	*l = *res.Data
+	*l = list
 
 	return nil
 }
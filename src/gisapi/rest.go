@@ -0,0 +1,114 @@
+// Package gisapi
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-12
+package gisapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// RESTSource reads networks from the v2 REST API of a GIS backend.
+type RESTSource struct {
+	url    string
+	client *Client
+}
+
+// NewRESTSource creates a source for the API at url, such as http://host:9090/api/v2.
+func NewRESTSource(url string, client *Client) *RESTSource {
+	return &RESTSource{url: url, client: client}
+}
+
+// Networks requests the networks the API key gives access to.
+func (s *RESTSource) Networks(ctx context.Context) ([]NetworkInfo, error) {
+	res, err := s.client.get(ctx, fmt.Sprintf(formatNetworkList, s.url))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	list := NetworkListResponse{}
+	if err := list.decode(res); err != nil {
+		return nil, err
+	}
+
+	return *list.Data, nil
+}
+
+// Nodes requests the nodes of a network.
+func (s *RESTSource) Nodes(ctx context.Context, uuid string) (*Stream[NodeGeometry], error) {
+	return restStream[NodeGeometry](ctx, s, formatNetworkNode, uuid)
+}
+
+// Links requests the links of a network.
+func (s *RESTSource) Links(ctx context.Context, uuid string) (*Stream[LinkGeometry], error) {
+	return restStream[LinkGeometry](ctx, s, formatNetworkLink, uuid)
+}
+
+// Network requests the description of a network.
+func (s *RESTSource) Network(ctx context.Context, uuid string) (NetworkInfo, error) {
+	if uuid == "" {
+		return NetworkInfo{}, ErrInvalidUUID
+	}
+
+	res, err := s.client.query(ctx, fmt.Sprintf(formatNetworkInfo, s.url), GeometryListRequest{UUID: uuid})
+	if err != nil {
+		return NetworkInfo{}, err
+	}
+	defer res.Body.Close()
+
+	info := NetworkInfoResponse{}
+	if err := info.decode(res); err != nil {
+		return NetworkInfo{}, err
+	}
+
+	return *info.Data, nil
+}
+
+// NodeAttributes requests the attributes of the selected nodes of a network.
+func (s *RESTSource) NodeAttributes(ctx context.Context, uuid string, refs FeatureRefs) ([]NodeAttributes, error) {
+	res := NodeAttributesResponse{}
+	if err := restAttributes(ctx, s, formatNodeAttributes, uuid, refs, &res.APIResponse); err != nil {
+		return nil, err
+	}
+
+	return *res.Data, nil
+}
+
+// LinkAttributes requests the attributes of the selected links of a network.
+func (s *RESTSource) LinkAttributes(ctx context.Context, uuid string, refs FeatureRefs) ([]LinkAttributes, error) {
+	res := LinkAttributesResponse{}
+	if err := restAttributes(ctx, s, formatLinkAttributes, uuid, refs, &res.APIResponse); err != nil {
+		return nil, err
+	}
+
+	return *res.Data, nil
+}
+
+func restAttributes[T any](ctx context.Context, s *RESTSource, format, uuid string, refs FeatureRefs, ar *APIResponse[T]) error {
+	if uuid == "" {
+		return ErrInvalidUUID
+	}
+
+	payload := AttributesRequest{GeometryListRequest: GeometryListRequest{UUID: uuid}, FeatureRefs: refs}
+	res, err := s.client.query(ctx, fmt.Sprintf(format, s.url), payload)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return ar.decode(res)
+}
+
+func restStream[T any](ctx context.Context, s *RESTSource, format, uuid string) (*Stream[T], error) {
+	if uuid == "" {
+		return nil, ErrInvalidUUID
+	}
+
+	res, err := s.client.query(ctx, fmt.Sprintf(format, s.url), GeometryListRequest{UUID: uuid})
+	if err != nil {
+		return nil, err
+	}
+
+	return openResponse[T](res)
+}
@@ -0,0 +1,117 @@
+// Package gisapi
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-12
+package gisapi
+
+import (
+	"context"
+	"sync"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/config"
+)
+
+// NetworkSource provides the networks of a GIS backend: the ones the backend lets the
+// configured API key see, and their geometry. Nodes and links are returned as
+// streams so large networks can be copied without holding them in memory; the caller
+// must close them.
+type NetworkSource interface {
+	Networks(ctx context.Context) ([]NetworkInfo, error)
+	Nodes(ctx context.Context, uuid string) (*Stream[NodeGeometry], error)
+	Links(ctx context.Context, uuid string) (*Stream[LinkGeometry], error)
+	Network(ctx context.Context, uuid string) (NetworkInfo, error)
+	NodeAttributes(ctx context.Context, uuid string, refs FeatureRefs) ([]NodeAttributes, error)
+	LinkAttributes(ctx context.Context, uuid string, refs FeatureRefs) ([]LinkAttributes, error)
+}
+
+var (
+	source      NetworkSource
+	sourceMutex sync.RWMutex
+
+	// profileSources holds the sources of the profiles other than the active one.
+	profileSources = map[string]NetworkSource{}
+)
+
+// NewSource creates the source configured by a profile, with its own client.
+func NewSource(profile *config.ProfileData) (NetworkSource, error) {
+	return newSource(profile, NewClient(profile.API.Client))
+}
+
+func newSource(profile *config.ProfileData, cl *Client) (NetworkSource, error) {
+	switch profile.Source.Kind {
+	case "", config.SourceREST:
+		return NewRESTSource(profileAPIURL(profile), cl), nil
+	case config.SourceFile:
+		return NewFileSource(profile.Source.Directory, profile.Source.Fallback), nil
+	}
+
+	return nil, ErrorUnknownSource(profile.Source.Kind)
+}
+
+// Source returns the source of the active profile.
+func Source() NetworkSource {
+	sourceMutex.RLock()
+	defer sourceMutex.RUnlock()
+
+	return source
+}
+
+// SetSource replaces the source of the active profile.
+func SetSource(s NetworkSource) {
+	sourceMutex.Lock()
+	defer sourceMutex.Unlock()
+
+	source = s
+}
+
+// ProfileSource returns the source of a configured profile. The active profile uses Source;
+// the others get a source of their own the first time they are asked for.
+func ProfileSource(name string) (NetworkSource, error) {
+	cfg := config.Get()
+	if cfg == nil || name == "" || name == cfg.Profile {
+		return Source(), nil
+	}
+
+	sourceMutex.Lock()
+	defer sourceMutex.Unlock()
+
+	if s, ok := profileSources[name]; ok {
+		return s, nil
+	}
+
+	profile, ok := cfg.Profiles[name]
+	if !ok {
+		return nil, ErrorUnknownProfile(name)
+	}
+	s, err := NewSource(&profile)
+	if err != nil {
+		return nil, err
+	}
+	profileSources[name] = s
+
+	return s, nil
+}
+
+// resetProfileSources drops the sources of the other profiles, so they follow a reloaded config.
+func resetProfileSources() {
+	sourceMutex.Lock()
+	defer sourceMutex.Unlock()
+
+	clear(profileSources)
+}
+
+// Collect reads a whole stream and closes it.
+func Collect[T any](s *Stream[T], err error) ([]T, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	list := []T{}
+	var v T
+	for s.Next(&v) {
+		list = append(list, v)
+		v = *new(T)
+	}
+
+	return list, s.Err()
+}
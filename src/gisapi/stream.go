@@ -0,0 +1,220 @@
+// Package gisapi
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-11
+package gisapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const (
+	fieldData         = "data"
+	fieldResponseCode = "responseCode"
+)
+
+// Stream decodes the data array of an APIResponse one element at a time, so the whole
+// list is never held in memory. Call Next until it returns false, then check Err.
+//
+// A failure responseCode sent before the data array is reported when the stream is opened.
+// One sent after it can only be reported by Err once the elements were consumed.
+// A bare JSON array, as found in exported files, is read as the data array.
+type Stream[T any] struct {
+	body io.ReadCloser
+	dec  *json.Decoder
+	err  error
+	done bool
+	bare bool
+}
+
+// OpenNodes opens the nodes of a network from the source of the active profile.
+func OpenNodes(ctx context.Context, uuid string) (*Stream[NodeGeometry], error) {
+	return Source().Nodes(ctx, uuid)
+}
+
+// OpenLinks opens the links of a network from the source of the active profile.
+func OpenLinks(ctx context.Context, uuid string) (*Stream[LinkGeometry], error) {
+	return Source().Links(ctx, uuid)
+}
+
+// OpenStream decodes an APIResponse read from r and positions the stream on the first
+// element of its data array. The stream takes ownership of r, which is closed on error.
+func OpenStream[T any](r io.ReadCloser) (*Stream[T], error) {
+	s := &Stream[T]{body: r, dec: json.NewDecoder(r)}
+	if err := s.open(); err != nil {
+		s.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// openResponse opens the stream of a response of the GIS API.
+func openResponse[T any](res *http.Response) (*Stream[T], error) {
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+
+		var apiErr APIError
+		return nil, apiErr.decode(res)
+	}
+
+	return OpenStream[T](res.Body)
+}
+
+// open reads the response up to the first element of the data array.
+func (s *Stream[T]) open() error {
+	tok, err := s.dec.Token()
+	if err != nil {
+		return ErrorDecodingBody(err)
+	}
+	if tok == json.Delim('[') {
+		s.bare = true
+		return nil
+	}
+	if tok != json.Delim('{') {
+		return ErrorDecodingBody(fmt.Errorf("expected an object or an array, got %v", tok))
+	}
+
+	for s.dec.More() {
+		key, err := s.key()
+		if err != nil {
+			return err
+		}
+
+		switch key {
+		case fieldData:
+			tok, err := s.dec.Token()
+			if err != nil {
+				return ErrorDecodingBody(err)
+			}
+			if tok == nil {
+				return ErrMissingDataField
+			}
+			if tok != json.Delim('[') {
+				return ErrorDecodingBody(fmt.Errorf("data is %v, not an array", tok))
+			}
+			return nil
+		case fieldResponseCode:
+			if err := s.responseCode(); err != nil {
+				return err
+			}
+		default:
+			if err := s.skip(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return ErrMissingDataField
+}
+
+// Next decodes the next element into v. It returns false at the end of the array or on error.
+func (s *Stream[T]) Next(v *T) bool {
+	if s.done {
+		return false
+	}
+
+	if !s.dec.More() {
+		s.done = true
+		s.err = s.finish()
+		return false
+	}
+
+	if err := s.dec.Decode(v); err != nil {
+		s.done = true
+		s.err = ErrorDecodingBody(err)
+		return false
+	}
+
+	return true
+}
+
+// Err returns the error that stopped Next, if any.
+func (s *Stream[T]) Err() error {
+	return s.err
+}
+
+// Close releases the upstream response.
+func (s *Stream[T]) Close() error {
+	s.done = true
+
+	return s.body.Close()
+}
+
+// finish reads the fields that follow the data array.
+func (s *Stream[T]) finish() error {
+	if err := s.expect(json.Delim(']')); err != nil {
+		return err
+	}
+	if s.bare {
+		return nil
+	}
+
+	for s.dec.More() {
+		key, err := s.key()
+		if err != nil {
+			return err
+		}
+
+		if key == fieldResponseCode {
+			err = s.responseCode()
+		} else {
+			err = s.skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.expect(json.Delim('}'))
+}
+
+func (s *Stream[T]) responseCode() error {
+	var code ResponseCode
+	if err := s.dec.Decode(&code); err != nil {
+		return ErrorDecodingBody(err)
+	}
+	if code != ResponseCodeSuccess {
+		return ErrorAPIResponseFailure(code)
+	}
+
+	return nil
+}
+
+func (s *Stream[T]) key() (string, error) {
+	tok, err := s.dec.Token()
+	if err != nil {
+		return "", ErrorDecodingBody(err)
+	}
+
+	key, ok := tok.(string)
+	if !ok {
+		return "", ErrorDecodingBody(fmt.Errorf("unexpected %v", tok))
+	}
+
+	return key, nil
+}
+
+func (s *Stream[T]) skip() error {
+	var v json.RawMessage
+	if err := s.dec.Decode(&v); err != nil {
+		return ErrorDecodingBody(err)
+	}
+
+	return nil
+}
+
+func (s *Stream[T]) expect(delim json.Delim) error {
+	tok, err := s.dec.Token()
+	if err != nil {
+		return ErrorDecodingBody(err)
+	}
+	if tok != delim {
+		return ErrorDecodingBody(fmt.Errorf("expected %v, got %v", delim, tok))
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+// Package gisapi
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-11
+package gisapi
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func openTestStream(body string) (*Stream[NodeGeometry], error) {
+	return OpenStream[NodeGeometry](io.NopCloser(strings.NewReader(body)))
+}
+
+func TestStreamDecodesElements(t *testing.T) {
+	s, err := openTestStream(`{"requestId":"r","responseCode":1000,"responseMessage":"ok","data":[` +
+		`{"id":1,"guid":"a","type":3,"geometry":[1,2,3]},{"id":2,"guid":"b","type":4,"geometry":[4,5,6]}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	var ids []int
+	var n NodeGeometry
+	for s.Next(&n) {
+		ids = append(ids, n.ID)
+	}
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Fatalf("Expected nodes 1 and 2, got %v", ids)
+	}
+	if s.Next(&n) {
+		t.Fatal("Expected the stream to stay finished")
+	}
+}
+
+func TestStreamFailureBeforeData(t *testing.T) {
+	_, err := openTestStream(`{"responseCode":2003,"data":[{"id":1}]}`)
+	if err == nil || !strings.Contains(err.Error(), "SQL Error") {
+		t.Fatalf("Expected the SQL error code, got %v", err)
+	}
+}
+
+func TestStreamFailureAfterData(t *testing.T) {
+	s, err := openTestStream(`{"data":[{"id":1}],"responseCode":2002}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var n NodeGeometry
+	for s.Next(&n) {
+	}
+	if err := s.Err(); err == nil || !strings.Contains(err.Error(), "Internal Error") {
+		t.Fatalf("Expected the internal error code, got %v", err)
+	}
+}
+
+func TestStreamMissingData(t *testing.T) {
+	for _, body := range []string{`{"responseCode":1000}`, `{"responseCode":1000,"data":null}`} {
+		if _, err := openTestStream(body); !errors.Is(err, ErrMissingDataField) {
+			t.Errorf("%s: expected ErrMissingDataField, got %v", body, err)
+		}
+	}
+}
+
+func TestStreamTruncated(t *testing.T) {
+	s, err := openTestStream(`{"responseCode":1000,"data":[{"id":1},{"id":`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count := 0
+	var n NodeGeometry
+	for s.Next(&n) {
+		count++
+	}
+	if count != 1 || s.Err() == nil {
+		t.Fatalf("Expected one node and an error, got %d, %v", count, s.Err())
+	}
+}
+
+func TestStreamBareArray(t *testing.T) {
+	s, err := openTestStream(`[{"id":7},{"id":8}]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count := 0
+	var n NodeGeometry
+	for s.Next(&n) {
+		count++
+	}
+	if count != 2 || s.Err() != nil {
+		t.Fatalf("Expected two nodes, got %d, %v", count, s.Err())
+	}
+}
@@ -0,0 +1,116 @@
+// Package gisapi
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-09
+package gisapi
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/config"
+)
+
+// Client is the HTTP client of one GIS backend. It is safe for concurrent use and keeps
+// its connections pooled between calls.
+type Client struct {
+	http    *http.Client
+	setup   config.ClientSetup
+	breaker *breaker
+}
+
+// NewClient creates a client; unset fields of the setup take their defaults.
+func NewClient(setup config.ClientSetup) *Client {
+	setup = setup.WithDefaults()
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{Timeout: setup.DialTimeout, KeepAlive: 30 * time.Second}).DialContext
+	transport.MaxIdleConnsPerHost = setup.MaxIdleConnsPerHost
+	transport.IdleConnTimeout = setup.IdleConnTimeout
+	// The body of a streamed network outlives any fixed timeout; the context bounds it.
+	transport.ResponseHeaderTimeout = setup.Timeout
+
+	return &Client{
+		http:    &http.Client{Transport: transport},
+		setup:   setup,
+		breaker: newBreaker(setup.BreakerThreshold, setup.BreakerCooldown),
+	}
+}
+
+// do sends the request built by newRequest. Idempotent requests are rebuilt and retried
+// after a network error or a 5xx status. The response of the last attempt is returned.
+func (cl *Client) do(ctx context.Context, idempotent bool, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	if !cl.breaker.allow() {
+		return nil, ErrCircuitOpen
+	}
+
+	attempts := 1
+	if idempotent {
+		attempts += cl.setup.Retries
+	}
+
+	var res *http.Response
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if err := cl.wait(ctx, attempt); err != nil {
+				cl.breaker.abandon()
+				return nil, err
+			}
+		}
+
+		var req *http.Request
+		req, err = newRequest()
+		if err != nil {
+			cl.breaker.abandon()
+			return nil, err
+		}
+
+		res, err = cl.http.Do(req.WithContext(ctx))
+		if !retryable(ctx, res, err) {
+			break
+		}
+		if attempt < attempts-1 && res != nil {
+			res.Body.Close()
+		}
+	}
+
+	// A cancelled caller says nothing about the health of the backend.
+	if ctx.Err() != nil {
+		cl.breaker.abandon()
+	} else {
+		cl.breaker.record(!retryable(ctx, res, err))
+	}
+
+	return res, err
+}
+
+// wait sleeps before a retry: the backoff doubles on every attempt, with jitter.
+func (cl *Client) wait(ctx context.Context, attempt int) error {
+	backoff := cl.setup.RetryBackoff << (attempt - 1)
+	if backoff <= 0 || backoff > cl.setup.MaxBackoff {
+		backoff = cl.setup.MaxBackoff
+	}
+	backoff = backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func retryable(ctx context.Context, res *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil && !errors.Is(err, context.Canceled)
+	}
+
+	return res.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,115 @@
+// Package gisapi
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-09
+package gisapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/config"
+)
+
+func get(url string) func() (*http.Request, error) {
+	return func() (*http.Request, error) { return http.NewRequest("GET", url, nil) }
+}
+
+func TestClientRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cl := NewClient(config.ClientSetup{Retries: 2, RetryBackoff: time.Millisecond})
+
+	res, err := cl.do(context.Background(), true, get(server.URL))
+	if err != nil || res.StatusCode != http.StatusOK {
+		t.Fatalf("Expected success on the third attempt, got %v, %v", res, err)
+	}
+	res.Body.Close()
+
+	calls.Store(0)
+	res, err = cl.do(context.Background(), false, get(server.URL))
+	if err != nil || res.StatusCode != http.StatusBadGateway || calls.Load() != 1 {
+		t.Errorf("Expected a single attempt for a non-idempotent call, got %d", calls.Load())
+	}
+	res.Body.Close()
+}
+
+func TestClientBreaker(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	cl := NewClient(config.ClientSetup{Retries: -1, BreakerThreshold: 2, BreakerCooldown: time.Hour})
+	for i := 0; i < 2; i++ {
+		res, err := cl.do(context.Background(), true, get(server.URL))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		res.Body.Close()
+	}
+
+	if _, err := cl.do(context.Background(), true, get(server.URL)); err != ErrCircuitOpen {
+		t.Errorf("Expected ErrCircuitOpen, got %v", err)
+	}
+}
+
+func TestClientBreakerRequestError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	cl := NewClient(config.ClientSetup{Retries: -1, BreakerThreshold: 1, BreakerCooldown: time.Millisecond})
+	res, err := cl.do(context.Background(), true, get(server.URL))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	res.Body.Close()
+	time.Sleep(2 * time.Millisecond)
+
+	// The trial call fails before reaching the backend, so another trial must be let through.
+	failing := func() (*http.Request, error) { return nil, errors.New("bad request") }
+	if _, err := cl.do(context.Background(), true, failing); err == nil || err == ErrCircuitOpen {
+		t.Fatalf("Expected the request error, got %v", err)
+	}
+	if res, err := cl.do(context.Background(), true, get(server.URL)); err == ErrCircuitOpen {
+		t.Errorf("Expected a new trial after an abandoned one")
+	} else if err == nil {
+		res.Body.Close()
+	}
+}
+
+func TestClientSlowBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		time.Sleep(50 * time.Millisecond)
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	cl := NewClient(config.ClientSetup{Timeout: 10 * time.Millisecond})
+	res, err := cl.do(context.Background(), true, get(server.URL))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer res.Body.Close()
+
+	if body, err := io.ReadAll(res.Body); err != nil || string(body) != "[]" {
+		t.Errorf("Expected the timeout to spare a slow body, got %q, %v", body, err)
+	}
+}
@@ -0,0 +1,79 @@
+// Package gisapi
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-15
+package gisapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	formatNetworkTypes = "%s/network/types"
+
+	typesFile = "network-types.json"
+)
+
+// ElementType describes a node or link type code as the GIS API sends it.
+type ElementType struct {
+	Code     int    `json:"code"`
+	Name     string `json:"name"`
+	Category string `json:"category,omitempty"`
+	Color    string `json:"color,omitempty"`
+	Icon     string `json:"icon,omitempty"`
+	Visible  *bool  `json:"visible,omitempty"`
+}
+
+// ElementTypes holds the node and link types of a backend.
+type ElementTypes struct {
+	Nodes []ElementType `json:"nodes"`
+	Links []ElementType `json:"links"`
+}
+
+type ElementTypesResponse struct {
+	APIResponse[ElementTypes]
+}
+
+// TypeSource is implemented by the sources that describe their type codes.
+type TypeSource interface {
+	Types(ctx context.Context) (ElementTypes, error)
+}
+
+// Types requests the node and link types of the backend.
+func (s *RESTSource) Types(ctx context.Context) (ElementTypes, error) {
+	res, err := s.client.get(ctx, fmt.Sprintf(formatNetworkTypes, s.url))
+	if err != nil {
+		return ElementTypes{}, err
+	}
+	defer res.Body.Close()
+
+	types := ElementTypesResponse{}
+	if err := types.decode(res); err != nil {
+		return ElementTypes{}, err
+	}
+
+	return *types.Data, nil
+}
+
+// Types reads network-types.json, holding an ElementTypes object. There are no types without it.
+func (s *FileSource) Types(_ context.Context) (ElementTypes, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, typesFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return ElementTypes{}, nil
+	}
+	if err != nil {
+		return ElementTypes{}, err
+	}
+
+	var types ElementTypes
+	if err := json.Unmarshal(data, &types); err != nil {
+		return ElementTypes{}, ErrorDecodingBody(err)
+	}
+
+	return types, nil
+}
@@ -0,0 +1,139 @@
+// Package gisdata
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-26
+package gisdata
+
+import "slices"
+
+// PointMove describes a point whose coordinates changed.
+type PointMove struct {
+	Id   string    `json:"id"`
+	From []float64 `json:"from"`
+	To   []float64 `json:"to"`
+}
+
+// LineRewire describes a line whose start or end point changed.
+type LineRewire struct {
+	Id   string  `json:"id"`
+	From GISLine `json:"from"`
+	To   GISLine `json:"to"`
+}
+
+// PolylineRewire describes a polyline whose node sequence changed.
+type PolylineRewire struct {
+	Id   string   `json:"id"`
+	From []string `json:"from"`
+	To   []string `json:"to"`
+}
+
+// PolygonReshape describes a polygon whose vertices changed.
+type PolygonReshape struct {
+	Id   string      `json:"id"`
+	From [][]float64 `json:"from"`
+	To   [][]float64 `json:"to"`
+}
+
+type PointsDiff struct {
+	Added   []GISPoint  `json:"added"`
+	Removed []GISPoint  `json:"removed"`
+	Moved   []PointMove `json:"moved"`
+}
+
+type LinesDiff struct {
+	Added   []GISLine    `json:"added"`
+	Removed []GISLine    `json:"removed"`
+	Rewired []LineRewire `json:"rewired"`
+}
+
+type PolylinesDiff struct {
+	Added   []GISPolyline    `json:"added"`
+	Removed []GISPolyline    `json:"removed"`
+	Rewired []PolylineRewire `json:"rewired"`
+}
+
+type PolygonsDiff struct {
+	Added    []GISPolygon     `json:"added"`
+	Removed  []GISPolygon     `json:"removed"`
+	Reshaped []PolygonReshape `json:"reshaped"`
+}
+
+// GISDiff is the structural difference between two versions of the same GIS data.
+// Elements are matched by their Id field.
+type GISDiff struct {
+	Points    PointsDiff    `json:"points"`
+	Lines     LinesDiff     `json:"lines"`
+	Polylines PolylinesDiff `json:"polylines"`
+	Polygons  PolygonsDiff  `json:"polygons"`
+}
+
+// Empty reports whether both versions are structurally identical.
+func (d GISDiff) Empty() bool {
+	return len(d.Points.Added)+len(d.Points.Removed)+len(d.Points.Moved)+
+		len(d.Lines.Added)+len(d.Lines.Removed)+len(d.Lines.Rewired)+
+		len(d.Polylines.Added)+len(d.Polylines.Removed)+len(d.Polylines.Rewired)+
+		len(d.Polygons.Added)+len(d.Polygons.Removed)+len(d.Polygons.Reshaped) == 0
+}
+
+// Diff compares two versions of GIS data. The results follow the element order of the
+// version they come from, so removed elements keep the old order and the rest the new one.
+func Diff(from, to GISData) GISDiff {
+	var diff GISDiff
+
+	diff.Points.Added, diff.Points.Removed, diff.Points.Moved = diffById(from.Points, to.Points,
+		func(p GISPoint) string { return p.Id },
+		func(old, p GISPoint) (PointMove, bool) {
+			return PointMove{Id: p.Id, From: old.Coordinates, To: p.Coordinates},
+				!slices.Equal(old.Coordinates, p.Coordinates)
+		})
+
+	diff.Lines.Added, diff.Lines.Removed, diff.Lines.Rewired = diffById(from.Lines, to.Lines,
+		func(l GISLine) string { return l.Id },
+		func(old, l GISLine) (LineRewire, bool) {
+			return LineRewire{Id: l.Id, From: old, To: l}, old.Start != l.Start || old.End != l.End
+		})
+
+	diff.Polylines.Added, diff.Polylines.Removed, diff.Polylines.Rewired = diffById(from.Polylines, to.Polylines,
+		func(pl GISPolyline) string { return pl.Id },
+		func(old, pl GISPolyline) (PolylineRewire, bool) {
+			return PolylineRewire{Id: pl.Id, From: old.Nodes, To: pl.Nodes}, !slices.Equal(old.Nodes, pl.Nodes)
+		})
+
+	diff.Polygons.Added, diff.Polygons.Removed, diff.Polygons.Reshaped = diffById(from.Polygons, to.Polygons,
+		func(pg GISPolygon) string { return pg.Id },
+		func(old, pg GISPolygon) (PolygonReshape, bool) {
+			return PolygonReshape{Id: pg.Id, From: old.Vertices, To: pg.Vertices},
+				!slices.EqualFunc(old.Vertices, pg.Vertices, slices.Equal[[]float64])
+		})
+
+	return diff
+}
+
+// diffById matches the elements of two versions by id. An element found in both is passed
+// to change, which reports whether it differs. The returned slices are never nil.
+func diffById[T, C any](from, to []T, id func(T) string, change func(old, cur T) (C, bool)) ([]T, []T, []C) {
+	added, removed, changed := []T{}, []T{}, []C{}
+
+	old := make(map[string]T, len(from))
+	for _, e := range from {
+		old[id(e)] = e
+	}
+	kept := make(map[string]bool, len(to))
+	for _, e := range to {
+		kept[id(e)] = true
+		prev, ok := old[id(e)]
+		if !ok {
+			added = append(added, e)
+			continue
+		}
+		if c, ok := change(prev, e); ok {
+			changed = append(changed, c)
+		}
+	}
+	for _, e := range from {
+		if !kept[id(e)] {
+			removed = append(removed, e)
+		}
+	}
+
+	return added, removed, changed
+}
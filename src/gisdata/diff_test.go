@@ -0,0 +1,64 @@
+// Package gisdata
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-26
+package gisdata
+
+import "testing"
+
+func sampleData() GISData {
+	return GISData{
+		Points: []GISPoint{
+			{Id: "n1", Coordinates: []float64{0, 0, 0}},
+			{Id: "n2", Coordinates: []float64{10, 0, 0}},
+			{Id: "n3", Coordinates: []float64{10, 10, 0}},
+		},
+		Lines: []GISLine{
+			{Id: "l1", Start: "n1", End: "n2"},
+			{Id: "l2", Start: "n2", End: "n3"},
+		},
+		Polylines: []GISPolyline{
+			{Id: "pl1", Nodes: []string{"n1", "n2", "n3"}},
+		},
+		Polygons: []GISPolygon{
+			{Id: "pg1", Vertices: [][]float64{{0, 0, 0}, {5, 0, 0}, {5, 5, 0}, {0, 0, 0}}},
+		},
+	}
+}
+
+func TestDiffIdentical(t *testing.T) {
+	if d := Diff(sampleData(), sampleData()); !d.Empty() {
+		t.Errorf("Expected an empty diff, got %+v", d)
+	}
+}
+
+func TestDiffChanges(t *testing.T) {
+	from := sampleData()
+	to := sampleData()
+
+	to.Points[1].Coordinates = []float64{12, 0, 0}
+	to.Points = append(to.Points[:2], GISPoint{Id: "n4", Coordinates: []float64{0, 10, 0}})
+	to.Lines[1].End = "n4"
+	to.Polylines[0].Nodes = []string{"n1", "n2", "n4"}
+	to.Polygons[0].Vertices[2] = []float64{6, 6, 0}
+
+	d := Diff(from, to)
+
+	if len(d.Points.Added) != 1 || d.Points.Added[0].Id != "n4" {
+		t.Errorf("Expected n4 added, got %+v", d.Points.Added)
+	}
+	if len(d.Points.Removed) != 1 || d.Points.Removed[0].Id != "n3" {
+		t.Errorf("Expected n3 removed, got %+v", d.Points.Removed)
+	}
+	if len(d.Points.Moved) != 1 || d.Points.Moved[0].Id != "n2" {
+		t.Errorf("Expected n2 moved, got %+v", d.Points.Moved)
+	}
+	if len(d.Lines.Rewired) != 1 || d.Lines.Rewired[0].From.End != "n3" || d.Lines.Rewired[0].To.End != "n4" {
+		t.Errorf("Expected l2 rewired from n3 to n4, got %+v", d.Lines.Rewired)
+	}
+	if len(d.Polylines.Rewired) != 1 {
+		t.Errorf("Expected pl1 rewired, got %+v", d.Polylines.Rewired)
+	}
+	if len(d.Polygons.Reshaped) != 1 {
+		t.Errorf("Expected pg1 reshaped, got %+v", d.Polygons.Reshaped)
+	}
+}
@@ -0,0 +1,26 @@
+// Package gisdata
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-24
+package gisdata
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// LoadCollectionsFile reads a JSON array of collections, such as web/json/collections.json.
+func LoadCollectionsFile(filePath string) (GISCollections, error) {
+	// Read the file
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse JSON data
+	var collections GISCollections
+	if err := json.Unmarshal(data, &collections); err != nil {
+		return nil, err
+	}
+
+	return collections, nil
+}
@@ -0,0 +1,47 @@
+// Package gisdata
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-24
+package gisdata
+
+// GISPoint represents a single point with an ID and coordinates.
+type GISPoint struct {
+	Id          string    `json:"id"`
+	Coordinates []float64 `json:"coordinates"`
+}
+
+// GISLine represents a line connecting two points by their IDs.
+type GISLine struct {
+	Id    string `json:"id"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// GISPolyline represents a sequence of connected points (nodes).
+type GISPolyline struct {
+	Id    string   `json:"id"`
+	Nodes []string `json:"nodes"`
+}
+
+// GISPolygon represents a closed shape defined by its vertices.
+type GISPolygon struct {
+	Id       string      `json:"id"`
+	Vertices [][]float64 `json:"vertices"`
+}
+
+// GISData is the main struct that aggregates all the data types.
+type GISData struct {
+	Points    []GISPoint    `json:"points"`
+	Lines     []GISLine     `json:"lines"`
+	Polylines []GISPolyline `json:"polylines"`
+	Polygons  []GISPolygon  `json:"polygons"`
+}
+
+// GISCollection is a named set of GIS data owned by a provider.
+type GISCollection struct {
+	Name     string   `json:"name"`
+	UUID     string   `json:"uuid"`
+	Provider string   `json:"provider,omitempty"`
+	GIS      *GISData `json:"gis,omitempty"`
+}
+
+type GISCollections []GISCollection
@@ -0,0 +1,185 @@
+// Package gltf
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-29
+package gltf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math"
+)
+
+const (
+	glbMagic     = 0x46546C67 // "glTF"
+	glbVersion   = 2
+	chunkJSON    = 0x4E4F534A // "JSON"
+	chunkBIN     = 0x004E4942 // "BIN\0"
+	glbHeaderLen = 12
+	chunkHeadLen = 8
+)
+
+// Builder accumulates meshes and their binary data into a single-buffer glTF document.
+type Builder struct {
+	doc Document
+	bin bytes.Buffer
+}
+
+func NewBuilder(generator string) *Builder {
+	return &Builder{
+		doc: Document{
+			Asset:  Asset{Version: "2.0", Generator: generator},
+			Scenes: []Scene{{Nodes: []int{}}},
+			Nodes:  []Node{},
+		},
+	}
+}
+
+// AddMaterial registers a flat-colored material and returns its index.
+func (b *Builder) AddMaterial(name string, color [4]float64) int {
+	b.doc.Materials = append(b.doc.Materials, Material{
+		Name: name,
+		PbrMetallicRoughness: PbrMetallicRoughness{
+			BaseColorFactor: color,
+			MetallicFactor:  0,
+			RoughnessFactor: 1,
+		},
+	})
+
+	return len(b.doc.Materials) - 1
+}
+
+// AddMesh stores a single-primitive mesh. Positions are xyz triples; indices may be nil.
+func (b *Builder) AddMesh(name string, mode, material int, positions []float32, indices []uint32) int {
+	primitive := Primitive{
+		Attributes: map[string]int{"POSITION": b.addPositions(positions)},
+		Material:   &material,
+		Mode:       mode,
+	}
+	if len(indices) > 0 {
+		accessor := b.addIndices(indices)
+		primitive.Indices = &accessor
+	}
+
+	b.doc.Meshes = append(b.doc.Meshes, Mesh{Name: name, Primitives: []Primitive{primitive}})
+
+	return len(b.doc.Meshes) - 1
+}
+
+// AddNode adds a node to the document and returns its index. A negative mesh adds an empty node.
+func (b *Builder) AddNode(name string, mesh int, extras map[string]any) int {
+	node := Node{Name: name, Extras: extras}
+	if mesh >= 0 {
+		node.Mesh = &mesh
+	}
+	b.doc.Nodes = append(b.doc.Nodes, node)
+
+	return len(b.doc.Nodes) - 1
+}
+
+// AddChild attaches child to parent.
+func (b *Builder) AddChild(parent, child int) {
+	b.doc.Nodes[parent].Children = append(b.doc.Nodes[parent].Children, child)
+}
+
+// SetTranslation moves a node.
+func (b *Builder) SetTranslation(node int, translation [3]float64) {
+	b.doc.Nodes[node].Translation = translation[:]
+}
+
+// AddRoot adds a node to the default scene.
+func (b *Builder) AddRoot(node int) {
+	b.doc.Scenes[0].Nodes = append(b.doc.Scenes[0].Nodes, node)
+}
+
+func (b *Builder) addPositions(positions []float32) int {
+	min := []float64{math.MaxFloat64, math.MaxFloat64, math.MaxFloat64}
+	max := []float64{-math.MaxFloat64, -math.MaxFloat64, -math.MaxFloat64}
+	for i, v := range positions {
+		c := i % 3
+		min[c] = math.Min(min[c], float64(v))
+		max[c] = math.Max(max[c], float64(v))
+	}
+
+	view := b.addBufferView(positions, TargetArrayBuffer)
+	b.doc.Accessors = append(b.doc.Accessors, Accessor{
+		BufferView:    view,
+		ComponentType: ComponentFloat,
+		Count:         len(positions) / 3,
+		Type:          "VEC3",
+		Min:           min,
+		Max:           max,
+	})
+
+	return len(b.doc.Accessors) - 1
+}
+
+func (b *Builder) addIndices(indices []uint32) int {
+	view := b.addBufferView(indices, TargetElementArrayBuffer)
+	b.doc.Accessors = append(b.doc.Accessors, Accessor{
+		BufferView:    view,
+		ComponentType: ComponentUnsignedInt,
+		Count:         len(indices),
+		Type:          "SCALAR",
+	})
+
+	return len(b.doc.Accessors) - 1
+}
+
+// addBufferView appends little-endian data to the binary buffer, keeping 4-byte alignment.
+func (b *Builder) addBufferView(data any, target int) int {
+	offset := b.bin.Len()
+	_ = binary.Write(&b.bin, binary.LittleEndian, data)
+	length := b.bin.Len() - offset
+	b.bin.Write(make([]byte, pad4(length)))
+
+	b.doc.BufferViews = append(b.doc.BufferViews, BufferView{
+		Buffer:     0,
+		ByteOffset: offset,
+		ByteLength: length,
+		Target:     target,
+	})
+
+	return len(b.doc.BufferViews) - 1
+}
+
+// WriteGLB writes the document and its buffer as a binary glTF file.
+func (b *Builder) WriteGLB(w io.Writer) error {
+	if len(b.doc.Meshes) == 0 {
+		return ErrEmptyScene
+	}
+
+	doc := b.doc
+	doc.Buffers = []Buffer{{ByteLength: b.bin.Len()}}
+
+	js, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	js = append(js, bytes.Repeat([]byte(" "), pad4(len(js)))...)
+
+	bin := b.bin.Bytes()
+	total := glbHeaderLen + chunkHeadLen + len(js) + chunkHeadLen + len(bin)
+
+	header := []uint32{
+		glbMagic, glbVersion, uint32(total),
+		uint32(len(js)), chunkJSON,
+	}
+	if err := binary.Write(w, binary.LittleEndian, header); err != nil {
+		return err
+	}
+	if _, err := w.Write(js); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.LittleEndian, []uint32{uint32(len(bin)), chunkBIN}); err != nil {
+		return err
+	}
+	_, err = w.Write(bin)
+
+	return err
+}
+
+func pad4(n int) int {
+	return (4 - n%4) % 4
+}
@@ -0,0 +1,10 @@
+// Package gltf
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-29
+package gltf
+
+import "errors"
+
+var (
+	ErrEmptyScene = errors.New("scene has no geometry to export")
+)
@@ -0,0 +1,94 @@
+// Package gltf
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-29
+package gltf
+
+// MIMEType is the media type of binary glTF files.
+const MIMEType = "model/gltf-binary"
+
+// Primitive modes, component types and buffer targets used by the exporter.
+const (
+	ModePoints    = 0
+	ModeLines     = 1
+	ModeTriangles = 4
+
+	ComponentFloat       = 5126
+	ComponentUnsignedInt = 5125
+
+	TargetArrayBuffer        = 34962
+	TargetElementArrayBuffer = 34963
+)
+
+// Document is the subset of the glTF 2.0 JSON schema written by the exporter.
+type Document struct {
+	Asset       Asset        `json:"asset"`
+	Scene       int          `json:"scene"`
+	Scenes      []Scene      `json:"scenes"`
+	Nodes       []Node       `json:"nodes"`
+	Meshes      []Mesh       `json:"meshes,omitempty"`
+	Materials   []Material   `json:"materials,omitempty"`
+	Accessors   []Accessor   `json:"accessors,omitempty"`
+	BufferViews []BufferView `json:"bufferViews,omitempty"`
+	Buffers     []Buffer     `json:"buffers,omitempty"`
+}
+
+type Asset struct {
+	Version   string `json:"version"`
+	Generator string `json:"generator,omitempty"`
+}
+
+type Scene struct {
+	Name  string `json:"name,omitempty"`
+	Nodes []int  `json:"nodes"`
+}
+
+type Node struct {
+	Name        string         `json:"name,omitempty"`
+	Mesh        *int           `json:"mesh,omitempty"`
+	Children    []int          `json:"children,omitempty"`
+	Translation []float64      `json:"translation,omitempty"`
+	Extras      map[string]any `json:"extras,omitempty"`
+}
+
+type Mesh struct {
+	Name       string      `json:"name,omitempty"`
+	Primitives []Primitive `json:"primitives"`
+}
+
+type Primitive struct {
+	Attributes map[string]int `json:"attributes"`
+	Indices    *int           `json:"indices,omitempty"`
+	Material   *int           `json:"material,omitempty"`
+	Mode       int            `json:"mode"`
+}
+
+type Material struct {
+	Name                 string               `json:"name,omitempty"`
+	PbrMetallicRoughness PbrMetallicRoughness `json:"pbrMetallicRoughness"`
+}
+
+type PbrMetallicRoughness struct {
+	BaseColorFactor [4]float64 `json:"baseColorFactor"`
+	MetallicFactor  float64    `json:"metallicFactor"`
+	RoughnessFactor float64    `json:"roughnessFactor"`
+}
+
+type Accessor struct {
+	BufferView    int       `json:"bufferView"`
+	ComponentType int       `json:"componentType"`
+	Count         int       `json:"count"`
+	Type          string    `json:"type"`
+	Min           []float64 `json:"min,omitempty"`
+	Max           []float64 `json:"max,omitempty"`
+}
+
+type BufferView struct {
+	Buffer     int `json:"buffer"`
+	ByteOffset int `json:"byteOffset"`
+	ByteLength int `json:"byteLength"`
+	Target     int `json:"target,omitempty"`
+}
+
+type Buffer struct {
+	ByteLength int `json:"byteLength"`
+}
@@ -0,0 +1,85 @@
+// Package gltf
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-29
+package gltf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+)
+
+func TestNetworkGLB(t *testing.T) {
+	nodes := gisapi.NodesData{
+		{ID: 1, Type: 1, Geometry: []float64{1000, 0, 0}},
+		{ID: 2, Type: 1, Geometry: []float64{1000, 0, 0}},
+		{ID: 3, Type: 2, Geometry: []float64{3000, 2000, 0}},
+	}
+	links := gisapi.LinksData{
+		{ID: 1, Type: 7, Geometry: [][]float64{{1000, 0, 0}, {2000, 1000, 0}, {3000, 2000, 0}}},
+	}
+
+	for _, style := range []string{NodeStylePoints, NodeStyleMesh} {
+		var out bytes.Buffer
+		if err := NetworkGLB("test", nodes, links, Options{NodeStyle: style}).WriteGLB(&out); err != nil {
+			t.Fatal(err)
+		}
+
+		data := out.Bytes()
+		if binary.LittleEndian.Uint32(data[0:4]) != glbMagic {
+			t.Fatal("Expected the glTF magic")
+		}
+		if int(binary.LittleEndian.Uint32(data[8:12])) != len(data) || len(data)%4 != 0 {
+			t.Fatalf("Invalid GLB length %d", len(data))
+		}
+
+		jsonLength := binary.LittleEndian.Uint32(data[12:16])
+		var doc Document
+		if err := json.Unmarshal(data[20:20+jsonLength], &doc); err != nil {
+			t.Fatal(err)
+		}
+
+		// Two node types and one link type.
+		if len(doc.Meshes) != 3 {
+			t.Fatalf("Expected 3 meshes, got %d", len(doc.Meshes))
+		}
+		if got := doc.Nodes[0].Translation; got[0] != 2 || got[1] != 1 {
+			t.Errorf("Expected the root to be centered at [2 1 0], got %v", got)
+		}
+		// The duplicated node is exported once and the three-point link as two segments.
+		lines := doc.Accessors[doc.Meshes[2].Primitives[0].Attributes["POSITION"]]
+		if lines.Count != 4 {
+			t.Errorf("Expected 4 line vertices, got %d", lines.Count)
+		}
+		if style == NodeStylePoints && doc.Accessors[0].Count != 1 {
+			t.Errorf("Expected 1 node of type 1, got %d", doc.Accessors[0].Count)
+		}
+	}
+}
+
+func TestNetworkGLBSkipsInvalidVertices(t *testing.T) {
+	links := gisapi.LinksData{
+		{ID: 1, Type: 7, Geometry: [][]float64{{}, {1000, 0, 0}, {}, {2000, 1000, 0}}},
+	}
+
+	var out bytes.Buffer
+	if err := NetworkGLB("test", nil, links, Options{}).WriteGLB(&out); err != nil {
+		t.Fatal(err)
+	}
+
+	data := out.Bytes()
+	jsonLength := binary.LittleEndian.Uint32(data[12:16])
+	var doc Document
+	if err := json.Unmarshal(data[20:20+jsonLength], &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the two valid vertices are joined, never the origin.
+	lines := doc.Accessors[doc.Meshes[0].Primitives[0].Attributes["POSITION"]]
+	if lines.Count != 2 {
+		t.Errorf("Expected 2 line vertices, got %d", lines.Count)
+	}
+}
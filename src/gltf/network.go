@@ -0,0 +1,196 @@
+// Package gltf
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-29
+package gltf
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+)
+
+// ScaleFactor matches the SCALE_FACTOR the viewer applies in scaleGeometry.
+const ScaleFactor = 0.001
+
+const (
+	NodeStylePoints = "points"
+	NodeStyleMesh   = "mesh"
+
+	// DefaultNodeSize is the radius of the viewer's node spheres, in scaled units.
+	DefaultNodeSize = 0.2
+)
+
+// Options controls how a network is exported.
+type Options struct {
+	// NodeStyle is NodeStylePoints or NodeStyleMesh. Mesh draws every node as a small octahedron.
+	NodeStyle string
+	// NodeSize is the octahedron radius in scaled units.
+	NodeSize float64
+}
+
+// nodePalette and linkPalette start with the viewer's default node and link colors.
+var (
+	nodePalette = [][4]float64{{1, 0, 0, 1}, {1, 0.6, 0, 1}, {0, 1, 0, 1}, {0.92, 0.53, 1, 1}, {0, 1, 1, 1}}
+	linkPalette = [][4]float64{{0, 0, 1, 1}, {0, 0.6, 1, 1}, {1, 1, 0, 1}, {1, 0, 1, 1}, {0.8, 0.8, 0.2, 1}}
+)
+
+// NetworkGLB builds a scene with one mesh per node type and one line mesh per link type.
+// Coordinates are scaled like the viewer and recentered around their bounding box, with the
+// offset stored as the root node translation to keep float32 precision.
+func NetworkGLB(name string, nodes gisapi.NodesData, links gisapi.LinksData, opts Options) *Builder {
+	if opts.NodeSize <= 0 {
+		opts.NodeSize = DefaultNodeSize
+	}
+
+	center := networkCenter(nodes, links)
+
+	b := NewBuilder("go-hynix-3d-viewer")
+	root := b.AddNode(name, -1, nil)
+	b.SetTranslation(root, center)
+	b.AddRoot(root)
+
+	nodesByType := map[int][][3]float64{}
+	seen := map[[3]float64]bool{}
+	for _, n := range nodes {
+		p, ok := scaled(n.Geometry, center)
+		if !ok || seen[p] {
+			continue
+		}
+		// Nodes sharing a position are drawn once, as the viewer does.
+		seen[p] = true
+		nodesByType[n.Type] = append(nodesByType[n.Type], p)
+	}
+
+	for i, t := range sortedTypes(nodesByType) {
+		material := b.AddMaterial(fmt.Sprintf("node-type-%d", t), nodePalette[i%len(nodePalette)])
+		var mesh int
+		if opts.NodeStyle == NodeStyleMesh {
+			positions, indices := octahedrons(nodesByType[t], float32(opts.NodeSize))
+			mesh = b.AddMesh(fmt.Sprintf("nodes-type-%d", t), ModeTriangles, material, positions, indices)
+		} else {
+			mesh = b.AddMesh(fmt.Sprintf("nodes-type-%d", t), ModePoints, material, flatten(nodesByType[t]), nil)
+		}
+		child := b.AddNode(fmt.Sprintf("nodes-type-%d", t), mesh, map[string]any{"kind": "nodes", "type": t, "count": len(nodesByType[t])})
+		b.AddChild(root, child)
+	}
+
+	segmentsByType := map[int][][3]float64{}
+	counts := map[int]int{}
+	for _, l := range links {
+		// Invalid vertices are skipped, joining the valid ones around them.
+		var prev [3]float64
+		hasPrev := false
+		for _, g := range l.Geometry {
+			p, ok := scaled(g, center)
+			if !ok {
+				continue
+			}
+			if hasPrev {
+				segmentsByType[l.Type] = append(segmentsByType[l.Type], prev, p)
+			}
+			prev, hasPrev = p, true
+		}
+		counts[l.Type]++
+	}
+
+	for i, t := range sortedTypes(segmentsByType) {
+		material := b.AddMaterial(fmt.Sprintf("link-type-%d", t), linkPalette[i%len(linkPalette)])
+		mesh := b.AddMesh(fmt.Sprintf("links-type-%d", t), ModeLines, material, flatten(segmentsByType[t]), nil)
+		child := b.AddNode(fmt.Sprintf("links-type-%d", t), mesh, map[string]any{"kind": "links", "type": t, "count": counts[t]})
+		b.AddChild(root, child)
+	}
+
+	return b
+}
+
+func networkCenter(nodes gisapi.NodesData, links gisapi.LinksData) [3]float64 {
+	var min, max [3]float64
+	first := true
+	add := func(g []float64) {
+		p, ok := scaled(g, [3]float64{})
+		if !ok {
+			return
+		}
+		for c := 0; c < 3; c++ {
+			if first || p[c] < min[c] {
+				min[c] = p[c]
+			}
+			if first || p[c] > max[c] {
+				max[c] = p[c]
+			}
+		}
+		first = false
+	}
+
+	for _, n := range nodes {
+		add(n.Geometry)
+	}
+	for _, l := range links {
+		for _, g := range l.Geometry {
+			add(g)
+		}
+	}
+
+	return [3]float64{(min[0] + max[0]) / 2, (min[1] + max[1]) / 2, (min[2] + max[2]) / 2}
+}
+
+// scaled applies the viewer scale and subtracts the center. Missing components default to zero.
+func scaled(g []float64, center [3]float64) ([3]float64, bool) {
+	if len(g) == 0 {
+		return [3]float64{}, false
+	}
+
+	var p [3]float64
+	for c := 0; c < 3 && c < len(g); c++ {
+		p[c] = g[c]*ScaleFactor - center[c]
+	}
+
+	return p, true
+}
+
+func sortedTypes(m map[int][][3]float64) []int {
+	types := make([]int, 0, len(m))
+	for t := range m {
+		types = append(types, t)
+	}
+	sort.Ints(types)
+
+	return types
+}
+
+func flatten(points [][3]float64) []float32 {
+	out := make([]float32, 0, len(points)*3)
+	for _, p := range points {
+		out = append(out, float32(p[0]), float32(p[1]), float32(p[2]))
+	}
+
+	return out
+}
+
+var (
+	octahedronVertices = [][3]float32{{1, 0, 0}, {-1, 0, 0}, {0, 1, 0}, {0, -1, 0}, {0, 0, 1}, {0, 0, -1}}
+	octahedronFaces    = []uint32{0, 2, 4, 2, 1, 4, 1, 3, 4, 3, 0, 4, 2, 0, 5, 1, 2, 5, 3, 1, 5, 0, 3, 5}
+)
+
+// octahedrons merges one octahedron per point into a single indexed triangle mesh.
+func octahedrons(points [][3]float64, radius float32) ([]float32, []uint32) {
+	positions := make([]float32, 0, len(points)*len(octahedronVertices)*3)
+	indices := make([]uint32, 0, len(points)*len(octahedronFaces))
+
+	for i, p := range points {
+		base := uint32(i * len(octahedronVertices))
+		for _, v := range octahedronVertices {
+			positions = append(positions,
+				float32(p[0])+v[0]*radius,
+				float32(p[1])+v[1]*radius,
+				float32(p[2])+v[2]*radius,
+			)
+		}
+		for _, f := range octahedronFaces {
+			indices = append(indices, base+f)
+		}
+	}
+
+	return positions, indices
+}
@@ -0,0 +1,170 @@
+// Package legend
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-15
+package legend
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/config"
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+)
+
+const (
+	KindNodes = "nodes"
+	KindLinks = "links"
+)
+
+// palette colours the types nobody described, so neighbouring codes stay distinguishable.
+var palette = []string{
+	"#1e88e5", "#e53935", "#43a047", "#fb8c00", "#8e24aa",
+	"#00acc1", "#fdd835", "#6d4c41", "#d81b60", "#546e7a",
+}
+
+// Type is how the viewer names, groups and draws a node or link type code.
+type Type struct {
+	Code     int    `json:"code"`
+	Name     string `json:"name"`
+	Category string `json:"category,omitempty"`
+	Color    string `json:"color"`
+	Icon     string `json:"icon,omitempty"`
+	Visible  bool   `json:"visible"`
+}
+
+// Entry is a type of a legend with the number of elements of that type.
+type Entry struct {
+	Type
+	Count int `json:"count"`
+}
+
+// Legend lists the node and link types present in a network, by code.
+type Legend struct {
+	Nodes []Entry `json:"nodes"`
+	Links []Entry `json:"links"`
+}
+
+// Registry maps the type codes of a profile to their description.
+type Registry struct {
+	nodes map[int]Type
+	links map[int]Type
+}
+
+// NewRegistry creates an empty registry; unknown codes get a generated description.
+func NewRegistry() *Registry {
+	return &Registry{nodes: map[int]Type{}, links: map[int]Type{}}
+}
+
+// AddAPI adds the types served by the GIS API.
+func (r *Registry) AddAPI(types gisapi.ElementTypes) {
+	for _, t := range types.Nodes {
+		r.nodes[t.Code] = merge(r.nodes[t.Code], t.Code, t.Name, t.Category, t.Color, t.Icon, t.Visible)
+	}
+	for _, t := range types.Links {
+		r.links[t.Code] = merge(r.links[t.Code], t.Code, t.Name, t.Category, t.Color, t.Icon, t.Visible)
+	}
+}
+
+// AddConfig adds the types of a profile. The fields they set override the ones of the
+// types already added with the same code.
+func (r *Registry) AddConfig(types config.TypesSetup) {
+	for _, t := range types.Nodes {
+		r.nodes[t.Code] = merge(r.nodes[t.Code], t.Code, t.Name, t.Category, t.Color, t.Icon, t.Visible)
+	}
+	for _, t := range types.Links {
+		r.links[t.Code] = merge(r.links[t.Code], t.Code, t.Name, t.Category, t.Color, t.Icon, t.Visible)
+	}
+}
+
+// Node describes a node type code.
+func (r *Registry) Node(code int) Type {
+	if t, ok := r.nodes[code]; ok {
+		return t
+	}
+
+	return merge(Type{}, code, "", "", "", "", nil)
+}
+
+// Link describes a link type code.
+func (r *Registry) Link(code int) Type {
+	if t, ok := r.links[code]; ok {
+		return t
+	}
+
+	return merge(Type{}, code, "", "", "", "", nil)
+}
+
+// Types returns every described type of a kind, by code.
+func (r *Registry) Types(kind string) []Type {
+	types := r.nodes
+	if kind == KindLinks {
+		types = r.links
+	}
+
+	list := make([]Type, 0, len(types))
+	for _, t := range types {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Code < list[j].Code })
+
+	return list
+}
+
+// Legend counts the types of the nodes and links of a network.
+func (r *Registry) Legend(nodes gisapi.NodesData, links gisapi.LinksData) Legend {
+	nodeCounts := map[int]int{}
+	for _, n := range nodes {
+		nodeCounts[n.Type]++
+	}
+	linkCounts := map[int]int{}
+	for _, l := range links {
+		linkCounts[l.Type]++
+	}
+
+	return Legend{
+		Nodes: entries(nodeCounts, r.Node),
+		Links: entries(linkCounts, r.Link),
+	}
+}
+
+func entries(counts map[int]int, describe func(int) Type) []Entry {
+	list := make([]Entry, 0, len(counts))
+	for code, count := range counts {
+		list = append(list, Entry{Type: describe(code), Count: count})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Code < list[j].Code })
+
+	return list
+}
+
+// merge sets the given fields of a type; the unset ones keep their value or get a default.
+func merge(t Type, code int, name, category, color, icon string, visible *bool) Type {
+	if t.Code != code || t.Name == "" {
+		t = Type{Code: code, Visible: true}
+	}
+
+	if name != "" {
+		t.Name = name
+	}
+	if category != "" {
+		t.Category = category
+	}
+	if color != "" {
+		t.Color = color
+	}
+	if icon != "" {
+		t.Icon = icon
+	}
+	if visible != nil {
+		t.Visible = *visible
+	}
+
+	if t.Name == "" {
+		t.Name = fmt.Sprintf("Type %d", code)
+	}
+	if t.Color == "" {
+		t.Color = palette[(code%len(palette)+len(palette))%len(palette)]
+	}
+
+	return t
+}
@@ -0,0 +1,48 @@
+// Package legend
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-15
+package legend
+
+import (
+	"testing"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/config"
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+)
+
+func TestRegistryLegend(t *testing.T) {
+	hidden := false
+	r := NewRegistry()
+	r.AddAPI(gisapi.ElementTypes{
+		Nodes: []gisapi.ElementType{{Code: 1, Name: "Valve", Color: "#000000"}, {Code: 2, Name: "Hydrant"}},
+		Links: []gisapi.ElementType{{Code: 7, Name: "Main"}},
+	})
+	r.AddConfig(config.TypesSetup{
+		Nodes: []config.TypeSetup{{Code: 1, Name: "Gate valve", Icon: "valve", Visible: &hidden}},
+	})
+
+	l := r.Legend(
+		gisapi.NodesData{{Type: 1}, {Type: 1}, {Type: 9}},
+		gisapi.LinksData{{Type: 7}},
+	)
+
+	if len(l.Nodes) != 2 || len(l.Links) != 1 {
+		t.Fatalf("Expected 2 node and 1 link entries, got %+v", l)
+	}
+
+	valve := l.Nodes[0]
+	if valve.Name != "Gate valve" || valve.Icon != "valve" || valve.Visible || valve.Count != 2 {
+		t.Errorf("Expected the config to override the API type, got %+v", valve)
+	}
+	if valve.Color != "#000000" {
+		t.Errorf("Expected the API colour to stay, got %q", valve.Color)
+	}
+
+	unknown := l.Nodes[1]
+	if unknown.Code != 9 || unknown.Name != "Type 9" || !unknown.Visible || unknown.Color == "" {
+		t.Errorf("Expected a generated type for an unknown code, got %+v", unknown)
+	}
+	if l.Links[0].Name != "Main" || l.Links[0].Count != 1 {
+		t.Errorf("Unexpected link entry %+v", l.Links[0])
+	}
+}
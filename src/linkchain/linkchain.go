@@ -0,0 +1,230 @@
+// Package linkchain
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-31
+package linkchain
+
+import (
+	"math"
+	"sort"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+)
+
+// keyResolution rounds coordinates the way the viewer's serializeVector does
+// after scaleGeometry: four decimals of the 0.001 scaled value.
+const keyResolution = 10
+
+// Chain is a polyline merged from consecutive links of the same type.
+type Chain struct {
+	Type     int         `json:"type"`
+	Closed   bool        `json:"closed"`
+	LinkIds  []int       `json:"linkIds"`
+	Geometry [][]float64 `json:"geometry"`
+}
+
+type coordKey [3]int64
+
+// Build merges links into chains. Link ends are the same vertex when they share a node id
+// or their end coordinates match. A vertex joins two links into one chain only when exactly
+// two link ends meet there and both links have the same type; every other vertex ends a chain.
+// Open chains run in ascending SequenceNo order and output is ordered by SequenceNo, then id.
+func Build(links gisapi.LinksData) []Chain {
+	order := make([]int, 0, len(links))
+	for i, l := range links {
+		if len(l.Geometry) > 0 {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		la, lb := links[order[a]], links[order[b]]
+		if la.SequenceNo != lb.SequenceNo {
+			return la.SequenceNo < lb.SequenceNo
+		}
+		return la.ID < lb.ID
+	})
+
+	b := newBuilder(links, order)
+
+	chains := make([]Chain, 0)
+	for _, i := range order {
+		for _, slot := range []int{2 * i, 2*i + 1} {
+			if b.used[i] || b.interior(b.vertex(slot)) {
+				continue
+			}
+			chains = append(chains, b.chain(b.walk(slot)))
+		}
+	}
+
+	// Whatever is left only has interior vertices, so it forms cycles.
+	for _, i := range order {
+		if !b.used[i] {
+			chains = append(chains, b.chain(b.walk(2*i)))
+		}
+	}
+
+	return chains
+}
+
+type step struct {
+	link    int
+	flipped bool
+}
+
+type builder struct {
+	links    gisapi.LinksData
+	parent   []int
+	incident map[int][]int
+	used     []bool
+}
+
+// newBuilder assigns every link end (slot 2i for the start and 2i+1 for the end of link i)
+// to a vertex, merging ends that share a node id or a coordinate key.
+func newBuilder(links gisapi.LinksData, order []int) *builder {
+	b := &builder{
+		links:    links,
+		parent:   make([]int, 2*len(links)),
+		incident: map[int][]int{},
+		used:     make([]bool, len(links)),
+	}
+	for i := range b.parent {
+		b.parent[i] = i
+	}
+
+	byNode := map[int]int{}
+	byCoord := map[coordKey]int{}
+	join := func(slot, nodeId int, position []float64) {
+		if nodeId > 0 {
+			if other, ok := byNode[nodeId]; ok {
+				b.union(slot, other)
+			} else {
+				byNode[nodeId] = slot
+			}
+		}
+		key := keyOf(position)
+		if other, ok := byCoord[key]; ok {
+			b.union(slot, other)
+		} else {
+			byCoord[key] = slot
+		}
+	}
+
+	for _, i := range order {
+		l := links[i]
+		join(2*i, l.StartNodeId, l.Geometry[0])
+		join(2*i+1, l.EndNodeId, l.Geometry[len(l.Geometry)-1])
+	}
+
+	for _, i := range order {
+		for _, slot := range []int{2 * i, 2*i + 1} {
+			v := b.find(slot)
+			b.incident[v] = append(b.incident[v], slot)
+		}
+	}
+
+	return b
+}
+
+func (b *builder) find(slot int) int {
+	for b.parent[slot] != slot {
+		b.parent[slot] = b.parent[b.parent[slot]]
+		slot = b.parent[slot]
+	}
+
+	return slot
+}
+
+func (b *builder) union(a, c int) {
+	ra, rc := b.find(a), b.find(c)
+	if ra != rc {
+		b.parent[rc] = ra
+	}
+}
+
+func (b *builder) vertex(slot int) int {
+	return b.find(slot)
+}
+
+func (b *builder) interior(v int) bool {
+	ends := b.incident[v]
+	return len(ends) == 2 && b.links[ends[0]/2].Type == b.links[ends[1]/2].Type
+}
+
+// walk follows links from the given entry slot until it reaches a chain end or a used link.
+func (b *builder) walk(enter int) []step {
+	var steps []step
+	for {
+		link := enter / 2
+		b.used[link] = true
+		steps = append(steps, step{link: link, flipped: enter%2 == 1})
+
+		exit := enter ^ 1
+		v := b.vertex(exit)
+		if !b.interior(v) {
+			return steps
+		}
+
+		ends := b.incident[v]
+		next := ends[0]
+		if next == exit {
+			next = ends[1]
+		}
+		if b.used[next/2] {
+			return steps
+		}
+		enter = next
+	}
+}
+
+func (b *builder) chain(steps []step) Chain {
+	first, last := steps[0], steps[len(steps)-1]
+	closed := b.vertex(2*first.link+boolInt(first.flipped)) == b.vertex(2*last.link+1-boolInt(last.flipped))
+
+	if !closed && b.links[first.link].SequenceNo > b.links[last.link].SequenceNo {
+		reversed := make([]step, len(steps))
+		for i, s := range steps {
+			reversed[len(steps)-1-i] = step{link: s.link, flipped: !s.flipped}
+		}
+		steps = reversed
+	}
+
+	c := Chain{
+		Type:    b.links[steps[0].link].Type,
+		Closed:  closed,
+		LinkIds: make([]int, 0, len(steps)),
+	}
+	for _, s := range steps {
+		l := b.links[s.link]
+		c.LinkIds = append(c.LinkIds, l.ID)
+
+		n := len(l.Geometry)
+		for k := 0; k < n; k++ {
+			g := l.Geometry[k]
+			if s.flipped {
+				g = l.Geometry[n-1-k]
+			}
+			if k == 0 && len(c.Geometry) > 0 && keyOf(c.Geometry[len(c.Geometry)-1]) == keyOf(g) {
+				continue
+			}
+			c.Geometry = append(c.Geometry, g)
+		}
+	}
+
+	return c
+}
+
+func keyOf(position []float64) coordKey {
+	var k coordKey
+	for i := 0; i < 3 && i < len(position); i++ {
+		k[i] = int64(math.Round(position[i] * keyResolution))
+	}
+
+	return k
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
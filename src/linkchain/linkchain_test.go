@@ -0,0 +1,64 @@
+// Package linkchain
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-31
+package linkchain
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+)
+
+func link(id, seq, typ, start, end int, geometry ...[]float64) gisapi.LinkGeometry {
+	return gisapi.LinkGeometry{ID: id, SequenceNo: seq, Type: typ, StartNodeId: start, EndNodeId: end, Geometry: geometry}
+}
+
+func TestBuildOpenChain(t *testing.T) {
+	links := gisapi.LinksData{
+		// Stored out of order and with the middle link reversed.
+		link(3, 3, 1, 0, 0, []float64{2, 0, 0}, []float64{3, 0, 0}),
+		link(2, 2, 1, 0, 0, []float64{2, 0, 0}, []float64{1, 0, 0}),
+		link(1, 1, 1, 0, 0, []float64{0, 0, 0}, []float64{1, 0, 0}),
+	}
+
+	chains := Build(links)
+	if len(chains) != 1 {
+		t.Fatalf("Expected 1 chain, got %+v", chains)
+	}
+	if !reflect.DeepEqual(chains[0].LinkIds, []int{1, 2, 3}) {
+		t.Errorf("Expected links [1 2 3], got %v", chains[0].LinkIds)
+	}
+	want := [][]float64{{0, 0, 0}, {1, 0, 0}, {2, 0, 0}, {3, 0, 0}}
+	if !reflect.DeepEqual(chains[0].Geometry, want) {
+		t.Errorf("Expected geometry %v, got %v", want, chains[0].Geometry)
+	}
+}
+
+func TestBuildSplitsAtJunctionsAndTypes(t *testing.T) {
+	links := gisapi.LinksData{
+		// A junction at node 2 joined by node id even though coordinates differ slightly.
+		link(1, 1, 1, 1, 2, []float64{0, 0, 0}, []float64{1, 0, 0}),
+		link(2, 2, 1, 2, 3, []float64{1.5, 0, 0}, []float64{2, 0, 0}),
+		link(3, 3, 1, 2, 4, []float64{1, 0, 0}, []float64{1, 1, 0}),
+		// A type change at node 4.
+		link(4, 4, 2, 4, 5, []float64{1, 1, 0}, []float64{1, 2, 0}),
+	}
+
+	if chains := Build(links); len(chains) != 4 {
+		t.Errorf("Expected 4 chains, got %+v", chains)
+	}
+}
+
+func TestBuildCycle(t *testing.T) {
+	links := gisapi.LinksData{
+		link(1, 1, 1, 0, 0, []float64{0, 0, 0}, []float64{1, 0, 0}),
+		link(2, 2, 1, 0, 0, []float64{1, 0, 0}, []float64{1, 1, 0}),
+		link(3, 3, 1, 0, 0, []float64{0, 0, 0}, []float64{1, 1, 0}),
+	}
+
+	chains := Build(links)
+	if len(chains) != 1 || !chains[0].Closed || len(chains[0].LinkIds) != 3 {
+		t.Errorf("Expected a single closed chain, got %+v", chains)
+	}
+}
@@ -0,0 +1,118 @@
+// Package memo
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-03
+package memo
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Cache keeps one value per key for a limited time. Expired values are dropped whenever the
+// cache is used, and a limited cache also drops its least recently used values when full.
+type Cache[T any] struct {
+	ttl     time.Duration
+	limit   int
+	mutex   sync.Mutex
+	entries map[string]*list.Element
+	// order holds the entries, most recently used first.
+	order *list.List
+}
+
+type cached[T any] struct {
+	key     string
+	once    sync.Once
+	value   T
+	err     error
+	created time.Time
+}
+
+// New creates a cache whose values are rebuilt after ttl.
+func New[T any](ttl time.Duration) *Cache[T] {
+	return NewLimited[T](ttl, 0)
+}
+
+// NewLimited creates a cache whose values are rebuilt after ttl and that holds at most
+// limit values. A limit of zero or less does not bound the cache.
+func NewLimited[T any](ttl time.Duration, limit int) *Cache[T] {
+	return &Cache[T]{ttl: ttl, limit: limit, entries: map[string]*list.Element{}, order: list.New()}
+}
+
+// Get returns the value of the key, calling build when it is missing or stale.
+// Concurrent callers for the same key share one build. Failed builds are not kept.
+func (c *Cache[T]) Get(key string, build func() (T, error)) (T, error) {
+	c.mutex.Lock()
+	c.sweep()
+	var entry *cached[T]
+	if e, ok := c.entries[key]; ok {
+		entry = e.Value.(*cached[T])
+		c.order.MoveToFront(e)
+	} else {
+		entry = &cached[T]{key: key, created: time.Now()}
+		c.entries[key] = c.order.PushFront(entry)
+		if c.limit > 0 && c.order.Len() > c.limit {
+			c.remove(c.order.Back())
+		}
+	}
+	c.mutex.Unlock()
+
+	entry.once.Do(func() {
+		entry.value, entry.err = build()
+	})
+
+	if entry.err != nil {
+		c.mutex.Lock()
+		if e, ok := c.entries[key]; ok && e.Value == entry {
+			c.remove(e)
+		}
+		c.mutex.Unlock()
+	}
+
+	return entry.value, entry.err
+}
+
+// Len returns the number of values held.
+func (c *Cache[T]) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.sweep()
+
+	return c.order.Len()
+}
+
+// Invalidate drops the value of the key.
+func (c *Cache[T]) Invalidate(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		c.remove(e)
+	}
+}
+
+// Clear drops every value.
+func (c *Cache[T]) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = map[string]*list.Element{}
+	c.order.Init()
+}
+
+// sweep drops the expired values. The caller holds the mutex.
+func (c *Cache[T]) sweep() {
+	for e := c.order.Front(); e != nil; {
+		next := e.Next()
+		if time.Since(e.Value.(*cached[T]).created) > c.ttl {
+			c.remove(e)
+		}
+		e = next
+	}
+}
+
+func (c *Cache[T]) remove(e *list.Element) {
+	delete(c.entries, e.Value.(*cached[T]).key)
+	c.order.Remove(e)
+}
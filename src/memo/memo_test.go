@@ -0,0 +1,65 @@
+// Package memo
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-03
+package memo
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGetBuildsOnce(t *testing.T) {
+	c := New[int](time.Hour)
+
+	builds := 0
+	build := func() (int, error) { builds++; return 7, nil }
+	for i := 0; i < 3; i++ {
+		if v, err := c.Get("a", build); err != nil || v != 7 {
+			t.Fatalf("Unexpected result %d, %v", v, err)
+		}
+	}
+	if builds != 1 {
+		t.Errorf("Expected a single build, got %d", builds)
+	}
+
+	if _, err := c.Get("b", func() (int, error) { return 0, errors.New("down") }); err == nil {
+		t.Fatal("Expected the build error")
+	}
+	if c.Len() != 1 {
+		t.Errorf("Expected the failed build to be dropped, got %d values", c.Len())
+	}
+}
+
+func TestLimitEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLimited[string](time.Hour, 2)
+	value := func(v string) func() (string, error) {
+		return func() (string, error) { return v, nil }
+	}
+
+	c.Get("a", value("a"))
+	c.Get("b", value("b"))
+	c.Get("a", value("a"))
+	c.Get("c", value("c"))
+
+	if c.Len() != 2 {
+		t.Fatalf("Expected 2 values, got %d", c.Len())
+	}
+	if v, _ := c.Get("a", value("rebuilt")); v != "a" {
+		t.Errorf("Expected the recently used value to stay, got %q", v)
+	}
+	if v, _ := c.Get("b", value("rebuilt")); v != "rebuilt" {
+		t.Errorf("Expected the least recently used value to be evicted, got %q", v)
+	}
+}
+
+func TestExpiredValuesAreSwept(t *testing.T) {
+	c := New[int](10 * time.Millisecond)
+	c.Get("a", func() (int, error) { return 1, nil })
+	c.Get("b", func() (int, error) { return 2, nil })
+
+	time.Sleep(20 * time.Millisecond)
+	if c.Len() != 0 {
+		t.Errorf("Expected expired values to be dropped, got %d", c.Len())
+	}
+}
@@ -0,0 +1,12 @@
+// Package scene
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-16
+package scene
+
+import "errors"
+
+var (
+	ErrInvalidRef  = errors.New("network must be a UUID or profile:UUID")
+	ErrNoNetworks  = errors.New("a scene needs at least one network")
+	ErrCRSMismatch = errors.New("networks of profiles without a CRS cannot be merged with other systems")
+)
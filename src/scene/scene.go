@@ -0,0 +1,262 @@
+// Package scene
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-16
+package scene
+
+import (
+	"math"
+	"strings"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/crs"
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+)
+
+const refSeparator = ":"
+
+// Ref names a network of a profile.
+type Ref struct {
+	Profile string `json:"profile"`
+	UUID    string `json:"uuid"`
+}
+
+// ParseRef reads "profile:uuid", or a bare uuid of the given profile.
+func ParseRef(s, profile string) (Ref, error) {
+	name, uuid, found := strings.Cut(strings.TrimSpace(s), refSeparator)
+	if !found {
+		name, uuid = profile, name
+	}
+
+	r := Ref{Profile: strings.TrimSpace(name), UUID: strings.TrimSpace(uuid)}
+	if r.Profile == "" || r.UUID == "" {
+		return Ref{}, ErrInvalidRef
+	}
+
+	return r, nil
+}
+
+func (r Ref) String() string {
+	return r.Profile + refSeparator + r.UUID
+}
+
+// Network is the geometry of a network as its backend serves it, in the CRS of its profile.
+// CRS is nil when the profile does not declare it.
+type Network struct {
+	Ref
+	Name  string
+	CRS   crs.CRS
+	Nodes gisapi.NodesData
+	Links gisapi.LinksData
+}
+
+// IDOffset is what was added to the ids of a network to keep them unique in the scene.
+// Subtracting it gives back the ids its backend knows.
+type IDOffset struct {
+	Nodes int `json:"nodes"`
+	Links int `json:"links"`
+}
+
+// Entry is a network of a scene. Its geometry is relative to its own origin, which sits at
+// Offset in the scene; BBox is in scene coordinates.
+type Entry struct {
+	Ref
+	Name      string           `json:"name,omitempty"`
+	Offset    []float64        `json:"offset"`
+	BBox      []float64        `json:"bbox"`
+	IDOffset  IDOffset         `json:"idOffset"`
+	NodeCount int              `json:"nodeCount"`
+	LinkCount int              `json:"linkCount"`
+	Nodes     gisapi.NodesData `json:"nodes,omitempty"`
+	Links     gisapi.LinksData `json:"links,omitempty"`
+}
+
+// Scene places several networks in one coordinate system. Scene coordinates are the ones of
+// CRS minus Origin; CRS is empty when the profiles do not declare it.
+type Scene struct {
+	CRS      string    `json:"crs,omitempty"`
+	Origin   []float64 `json:"origin"`
+	BBox     []float64 `json:"bbox"`
+	Networks []Entry   `json:"networks"`
+}
+
+// WithoutGeometry drops the nodes and links, leaving the manifest.
+func (s *Scene) WithoutGeometry() {
+	for i := range s.Networks {
+		s.Networks[i].Nodes, s.Networks[i].Links = nil, nil
+	}
+}
+
+// Build merges networks into a scene in the target system, or in the one of the first
+// network when target is nil. Networks of profiles without a CRS can only be merged with
+// networks of other such profiles, whose coordinates are taken as they are.
+func Build(networks []Network, target crs.CRS) (*Scene, error) {
+	if len(networks) == 0 {
+		return nil, ErrNoNetworks
+	}
+	if target == nil {
+		target = networks[0].CRS
+	}
+	for _, n := range networks {
+		if !sameCRS(n.CRS, target) && (n.CRS == nil || target == nil) {
+			return nil, ErrCRSMismatch
+		}
+	}
+
+	entries := make([]Entry, len(networks))
+	bounds := make([][]float64, len(networks))
+	all := gisapi.NewBounds()
+	for i, n := range networks {
+		t := crs.NewTransformer(n.CRS, target)
+		nodes, links := t.Nodes(n.Nodes), t.Links(n.Links)
+
+		b := gisapi.NewBounds()
+		for _, node := range nodes {
+			b.AddNode(node)
+			all.AddNode(node)
+		}
+		for _, link := range links {
+			b.AddLink(link)
+			all.AddLink(link)
+		}
+
+		entries[i] = Entry{
+			Ref:       n.Ref,
+			Name:      n.Name,
+			NodeCount: len(nodes),
+			LinkCount: len(links),
+			Nodes:     nodes,
+			Links:     links,
+		}
+		bounds[i] = b.BBox()
+	}
+
+	s := &Scene{Origin: center(all.BBox()), Networks: entries}
+	if target != nil {
+		s.CRS = crs.Identifier(target)
+	}
+	if s.Origin == nil {
+		s.Origin = []float64{0, 0, 0}
+	}
+	s.BBox = shift(all.BBox(), s.Origin)
+
+	for i := range entries {
+		e := &entries[i]
+
+		origin := center(bounds[i])
+		if origin == nil {
+			origin = s.Origin
+		}
+		local := crs.NewTransformer(nil, nil).WithOrigin(origin)
+		e.Nodes, e.Links = local.Nodes(e.Nodes), local.Links(e.Links)
+
+		e.Offset = shift(origin, s.Origin)
+		e.BBox = shift(bounds[i], s.Origin)
+	}
+	resolveIDs(entries)
+
+	return s, nil
+}
+
+func sameCRS(a, b crs.CRS) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return a.Code() == b.Code()
+}
+
+// center returns the center of a bbox rounded to whole units, or nil for no bbox.
+func center(bbox []float64) []float64 {
+	if bbox == nil {
+		return nil
+	}
+
+	c := make([]float64, 3)
+	for i := range c {
+		c[i] = math.Round((bbox[i] + bbox[i+3]) / 2)
+	}
+
+	return c
+}
+
+// shift subtracts origin from a position or from both corners of a bbox.
+func shift(v, origin []float64) []float64 {
+	if v == nil {
+		return nil
+	}
+
+	out := make([]float64, len(v))
+	for i := range v {
+		out[i] = v[i] - origin[i%len(origin)]
+	}
+
+	return out
+}
+
+// idRange is the span of the ids of one kind in a network.
+type idRange struct {
+	lo, hi int
+	empty  bool
+}
+
+func (r idRange) overlaps(o idRange) bool {
+	return !r.empty && !o.empty && r.lo <= o.hi && o.lo <= r.hi
+}
+
+// resolveIDs keeps the ids of a network when their range does not overlap the ranges of
+// the networks before it, and otherwise moves them past the highest id used so far. Links
+// follow the nodes they connect.
+func resolveIDs(entries []Entry) {
+	var nodeRanges, linkRanges []idRange
+	for i := range entries {
+		e := &entries[i]
+
+		nodes := idRange{empty: true}
+		for _, n := range e.Nodes {
+			nodes = nodes.add(n.ID)
+		}
+		links := idRange{empty: true}
+		for _, l := range e.Links {
+			links = links.add(l.ID)
+		}
+
+		e.IDOffset.Nodes, nodeRanges = place(nodes, nodeRanges)
+		e.IDOffset.Links, linkRanges = place(links, linkRanges)
+
+		for j := range e.Nodes {
+			e.Nodes[j].ID += e.IDOffset.Nodes
+		}
+		for j := range e.Links {
+			e.Links[j].ID += e.IDOffset.Links
+			e.Links[j].StartNodeId += e.IDOffset.Nodes
+			e.Links[j].EndNodeId += e.IDOffset.Nodes
+		}
+	}
+}
+
+func (r idRange) add(id int) idRange {
+	if r.empty {
+		return idRange{lo: id, hi: id}
+	}
+
+	return idRange{lo: min(r.lo, id), hi: max(r.hi, id)}
+}
+
+// place returns the offset that keeps r clear of the used ranges, and the ranges with r added.
+func place(r idRange, used []idRange) (int, []idRange) {
+	if r.empty {
+		return 0, used
+	}
+
+	collides, top := false, math.MinInt
+	for _, u := range used {
+		collides = collides || r.overlaps(u)
+		top = max(top, u.hi)
+	}
+
+	offset := 0
+	if collides {
+		offset = top + 1 - r.lo
+	}
+
+	return offset, append(used, idRange{lo: r.lo + offset, hi: r.hi + offset})
+}
@@ -0,0 +1,92 @@
+// Package scene
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-16
+package scene
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/crs"
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+)
+
+func TestParseRef(t *testing.T) {
+	if r, err := ParseRef(" n1 ", "dev"); err != nil || r != (Ref{Profile: "dev", UUID: "n1"}) {
+		t.Errorf("Expected the default profile, got %+v, %v", r, err)
+	}
+	if r, err := ParseRef("plant-b:n2", "dev"); err != nil || r.String() != "plant-b:n2" {
+		t.Errorf("Expected the given profile, got %+v, %v", r, err)
+	}
+	for _, s := range []string{"", ":n1", "dev:"} {
+		if _, err := ParseRef(s, "dev"); !errors.Is(err, ErrInvalidRef) {
+			t.Errorf("Expected %q to be rejected, got %v", s, err)
+		}
+	}
+}
+
+func TestBuildPlacesAndRenumbers(t *testing.T) {
+	a := Network{
+		Ref:   Ref{Profile: "dev", UUID: "a"},
+		Nodes: gisapi.NodesData{{ID: 1, Geometry: []float64{0, 0, 0}}, {ID: 2, Geometry: []float64{10, 0, 0}}},
+		Links: gisapi.LinksData{{ID: 1, StartNodeId: 1, EndNodeId: 2, Geometry: [][]float64{{0, 0, 0}, {10, 0, 0}}}},
+	}
+	b := Network{
+		Ref:   Ref{Profile: "other", UUID: "b"},
+		Nodes: gisapi.NodesData{{ID: 2, Geometry: []float64{100, 0, 0}}, {ID: 3, Geometry: []float64{110, 0, 0}}},
+		Links: gisapi.LinksData{{ID: 5, StartNodeId: 2, EndNodeId: 3, Geometry: [][]float64{{100, 0, 0}, {110, 0, 0}}}},
+	}
+
+	s, err := Build([]Network{a, b}, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if !slices.Equal(s.Origin, []float64{55, 0, 0}) || !slices.Equal(s.BBox, []float64{-55, 0, 0, 55, 0, 0}) {
+		t.Errorf("Unexpected scene origin %v and bbox %v", s.Origin, s.BBox)
+	}
+
+	first, second := s.Networks[0], s.Networks[1]
+	if !slices.Equal(first.Offset, []float64{-50, 0, 0}) || !slices.Equal(second.Offset, []float64{50, 0, 0}) {
+		t.Errorf("Unexpected offsets %v and %v", first.Offset, second.Offset)
+	}
+	if !slices.Equal(second.Nodes[0].Geometry, []float64{-5, 0, 0}) {
+		t.Errorf("Expected geometry relative to the network origin, got %v", second.Nodes[0].Geometry)
+	}
+	if !slices.Equal(second.BBox, []float64{45, 0, 0, 55, 0, 0}) {
+		t.Errorf("Expected the bbox in scene coordinates, got %v", second.BBox)
+	}
+
+	if first.IDOffset != (IDOffset{}) || first.Nodes[0].ID != 1 {
+		t.Errorf("Expected the first network to keep its ids, got %+v", first.IDOffset)
+	}
+	if second.IDOffset != (IDOffset{Nodes: 1, Links: 0}) {
+		t.Errorf("Expected the colliding node ids to move, got %+v", second.IDOffset)
+	}
+	if l := second.Links[0]; l.ID != 5 || l.StartNodeId != 3 || l.EndNodeId != 4 {
+		t.Errorf("Expected the links to follow their nodes, got %+v", l)
+	}
+}
+
+func TestBuildReprojects(t *testing.T) {
+	wgs84, _ := crs.ByCode(crs.CodeWGS84)
+	mercator, _ := crs.ByCode(crs.CodeWebMercator)
+	n := Network{
+		Ref:   Ref{Profile: "dev", UUID: "a"},
+		CRS:   wgs84,
+		Nodes: gisapi.NodesData{{ID: 1, Geometry: []float64{1, 0, 0}}},
+	}
+
+	s, err := Build([]Network{n}, mercator)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if s.CRS != crs.Identifier(mercator) || s.Origin[0] < 100000 {
+		t.Errorf("Expected the scene in Web Mercator, got %s at %v", s.CRS, s.Origin)
+	}
+
+	if _, err := Build([]Network{n, {Ref: Ref{Profile: "raw", UUID: "b"}}}, nil); !errors.Is(err, ErrCRSMismatch) {
+		t.Errorf("Expected a network without CRS to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+// Package spatial
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-03
+package spatial
+
+import "math"
+
+// Point is a 3D coordinate.
+type Point [3]float64
+
+// Box is an axis-aligned 3D bounding box.
+type Box struct {
+	Min Point `json:"min"`
+	Max Point `json:"max"`
+}
+
+// PointFrom reads up to three coordinates; missing ones are zero.
+func PointFrom(coords []float64) Point {
+	var p Point
+	copy(p[:], coords)
+
+	return p
+}
+
+func emptyBox() Box {
+	inf := math.Inf(1)
+	return Box{Min: Point{inf, inf, inf}, Max: Point{-inf, -inf, -inf}}
+}
+
+func segmentBox(a, b Point) Box {
+	box := emptyBox()
+	box.extendPoint(a)
+	box.extendPoint(b)
+
+	return box
+}
+
+func (b *Box) extendPoint(p Point) {
+	for i := 0; i < 3; i++ {
+		b.Min[i] = math.Min(b.Min[i], p[i])
+		b.Max[i] = math.Max(b.Max[i], p[i])
+	}
+}
+
+func (b *Box) extend(o Box) {
+	b.extendPoint(o.Min)
+	b.extendPoint(o.Max)
+}
+
+// Intersects reports whether both boxes overlap, touching edges included.
+func (b Box) Intersects(o Box) bool {
+	for i := 0; i < 3; i++ {
+		if b.Max[i] < o.Min[i] || o.Max[i] < b.Min[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Contains reports whether p lies inside the box.
+func (b Box) Contains(p Point) bool {
+	for i := 0; i < 3; i++ {
+		if p[i] < b.Min[i] || p[i] > b.Max[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// distance returns the distance from p to the closest point of the box.
+func (b Box) distance(p Point) float64 {
+	sum := 0.0
+	for i := 0; i < 3; i++ {
+		d := 0.0
+		if p[i] < b.Min[i] {
+			d = b.Min[i] - p[i]
+		} else if p[i] > b.Max[i] {
+			d = p[i] - b.Max[i]
+		}
+		sum += d * d
+	}
+
+	return math.Sqrt(sum)
+}
+
+func (b Box) center(axis int) float64 {
+	return (b.Min[axis] + b.Max[axis]) / 2
+}
+
+// SegmentDistance returns the distance from p to the segment ab.
+func SegmentDistance(p, a, b Point) float64 {
+	var ab, ap Point
+	lengthSq, dot := 0.0, 0.0
+	for i := 0; i < 3; i++ {
+		ab[i] = b[i] - a[i]
+		ap[i] = p[i] - a[i]
+		lengthSq += ab[i] * ab[i]
+		dot += ab[i] * ap[i]
+	}
+
+	t := 0.0
+	if lengthSq > 0 {
+		t = math.Max(0, math.Min(1, dot/lengthSq))
+	}
+
+	sum := 0.0
+	for i := 0; i < 3; i++ {
+		d := a[i] + t*ab[i] - p[i]
+		sum += d * d
+	}
+
+	return math.Sqrt(sum)
+}
+
+// segmentIntersectsBox clips the segment ab against the box (slab method).
+func segmentIntersectsBox(a, b Point, box Box) bool {
+	t0, t1 := 0.0, 1.0
+	for i := 0; i < 3; i++ {
+		d := b[i] - a[i]
+		if d == 0 {
+			if a[i] < box.Min[i] || a[i] > box.Max[i] {
+				return false
+			}
+			continue
+		}
+		near, far := (box.Min[i]-a[i])/d, (box.Max[i]-a[i])/d
+		if near > far {
+			near, far = far, near
+		}
+		t0, t1 = math.Max(t0, near), math.Min(t1, far)
+		if t0 > t1 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,202 @@
+// Package spatial
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-03
+package spatial
+
+import (
+	"math"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+)
+
+// Kind tells nodes and link segments apart.
+type Kind string
+
+const (
+	KindNode Kind = "node"
+	KindLink Kind = "link"
+)
+
+// Hit is an element found by a nearest-neighbour query.
+// Segment is the index of the closest segment of a link polyline.
+type Hit struct {
+	Kind     Kind    `json:"kind"`
+	ID       int     `json:"id"`
+	Segment  int     `json:"segment,omitempty"`
+	Distance float64 `json:"distance"`
+}
+
+// Result holds the elements matching a query. A link is listed once even when
+// several of its segments match. Hits is only set by Nearest.
+type Result struct {
+	Nodes gisapi.NodesData `json:"nodes"`
+	Links gisapi.LinksData `json:"links"`
+	Hits  []Hit            `json:"hits,omitempty"`
+}
+
+// Index is an R-tree over node points and link segments of a network.
+type Index struct {
+	nodes   gisapi.NodesData
+	links   gisapi.LinksData
+	entries []entry
+	root    *rnode
+}
+
+// New indexes the nodes and links. Nodes without coordinates are skipped and a link
+// polyline with a single point is indexed as a point.
+func New(nodes gisapi.NodesData, links gisapi.LinksData) *Index {
+	idx := &Index{nodes: nodes, links: links}
+
+	for i, n := range nodes {
+		if len(n.Geometry) == 0 {
+			continue
+		}
+		p := PointFrom(n.Geometry)
+		idx.entries = append(idx.entries, entry{kind: KindNode, element: i, a: p, b: p, box: segmentBox(p, p)})
+	}
+
+	for i, l := range links {
+		if len(l.Geometry) == 1 {
+			p := PointFrom(l.Geometry[0])
+			idx.entries = append(idx.entries, entry{kind: KindLink, element: i, a: p, b: p, box: segmentBox(p, p)})
+			continue
+		}
+		for s := 1; s < len(l.Geometry); s++ {
+			a, b := PointFrom(l.Geometry[s-1]), PointFrom(l.Geometry[s])
+			idx.entries = append(idx.entries, entry{kind: KindLink, element: i, segment: s - 1, a: a, b: b, box: segmentBox(a, b)})
+		}
+	}
+
+	idx.root = bulkLoad(idx.entries)
+
+	return idx
+}
+
+// Bounds returns the box around every indexed element.
+func (idx *Index) Bounds() Box {
+	return idx.root.box
+}
+
+// Size returns the number of indexed points and segments.
+func (idx *Index) Size() int {
+	return len(idx.entries)
+}
+
+// InBox returns the nodes inside the box and the links with a segment crossing it.
+func (idx *Index) InBox(box Box, kinds ...Kind) Result {
+	c := newCollector(idx, kinds)
+	idx.root.search(box, idx.entries, func(id int) {
+		e := idx.entries[id]
+		if e.kind == KindNode || segmentIntersectsBox(e.a, e.b, box) {
+			c.add(e)
+		}
+	})
+
+	return c.result()
+}
+
+// InSphere returns the elements closer than radius to the center.
+func (idx *Index) InSphere(center Point, radius float64, kinds ...Kind) Result {
+	box := Box{
+		Min: Point{center[0] - radius, center[1] - radius, center[2] - radius},
+		Max: Point{center[0] + radius, center[1] + radius, center[2] + radius},
+	}
+
+	c := newCollector(idx, kinds)
+	idx.root.search(box, idx.entries, func(id int) {
+		e := idx.entries[id]
+		if SegmentDistance(center, e.a, e.b) <= radius {
+			c.add(e)
+		}
+	})
+
+	return c.result()
+}
+
+// Nearest returns the k elements closest to p, closest first.
+// A maxDistance of zero or less does not limit the search.
+func (idx *Index) Nearest(p Point, k int, maxDistance float64, kinds ...Kind) Result {
+	if maxDistance <= 0 {
+		maxDistance = math.Inf(1)
+	}
+
+	c := newCollector(idx, kinds)
+	hits := make([]Hit, 0, k)
+	idx.root.nearest(p, idx.entries, func(id int, distance float64) bool {
+		if len(hits) >= k || distance > maxDistance {
+			return false
+		}
+		e := idx.entries[id]
+		if c.add(e) {
+			hits = append(hits, Hit{Kind: e.kind, ID: c.id(e), Segment: e.segment, Distance: distance})
+		}
+		return true
+	})
+
+	result := c.result()
+	result.Hits = hits
+
+	return result
+}
+
+// collector de-duplicates matching entries into a Result.
+type collector struct {
+	idx   *Index
+	kinds map[Kind]bool
+	nodes map[int]bool
+	links map[int]bool
+	out   Result
+}
+
+func newCollector(idx *Index, kinds []Kind) *collector {
+	c := &collector{
+		idx:   idx,
+		nodes: map[int]bool{},
+		links: map[int]bool{},
+		out:   Result{Nodes: gisapi.NodesData{}, Links: gisapi.LinksData{}},
+	}
+	if len(kinds) > 0 {
+		c.kinds = map[Kind]bool{}
+		for _, k := range kinds {
+			c.kinds[k] = true
+		}
+	}
+
+	return c
+}
+
+// add records the element of the entry and reports whether it was new and wanted.
+func (c *collector) add(e entry) bool {
+	if c.kinds != nil && !c.kinds[e.kind] {
+		return false
+	}
+
+	switch e.kind {
+	case KindNode:
+		if c.nodes[e.element] {
+			return false
+		}
+		c.nodes[e.element] = true
+		c.out.Nodes = append(c.out.Nodes, c.idx.nodes[e.element])
+	case KindLink:
+		if c.links[e.element] {
+			return false
+		}
+		c.links[e.element] = true
+		c.out.Links = append(c.out.Links, c.idx.links[e.element])
+	}
+
+	return true
+}
+
+func (c *collector) id(e entry) int {
+	if e.kind == KindNode {
+		return c.idx.nodes[e.element].ID
+	}
+
+	return c.idx.links[e.element].ID
+}
+
+func (c *collector) result() Result {
+	return c.out
+}
@@ -0,0 +1,180 @@
+// Package spatial
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-03
+package spatial
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// nodeCapacity is the fan-out of the tree.
+const nodeCapacity = 16
+
+// entry is a single point or segment stored in the tree.
+// A point has both ends equal.
+type entry struct {
+	kind    Kind
+	element int // index into Index.nodes or Index.links
+	segment int
+	a, b    Point
+	box     Box
+}
+
+// rnode is a node of a static R-tree. Leaves hold entries, other nodes hold children.
+type rnode struct {
+	box      Box
+	children []*rnode
+	entries  []int
+}
+
+// bulkLoad builds the tree with Sort-Tile-Recursive packing.
+func bulkLoad(entries []entry) *rnode {
+	if len(entries) == 0 {
+		return &rnode{box: emptyBox()}
+	}
+
+	ids := make([]int, len(entries))
+	for i := range ids {
+		ids[i] = i
+	}
+
+	level := packLeaves(entries, ids)
+	for len(level) > 1 {
+		level = packNodes(level)
+	}
+
+	return level[0]
+}
+
+func packLeaves(entries []entry, ids []int) []*rnode {
+	boxes := func(i int) Box { return entries[ids[i]].box }
+	groups := tile(len(ids), boxes, func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+
+	leaves := make([]*rnode, 0, len(groups))
+	for _, g := range groups {
+		leaf := &rnode{box: emptyBox(), entries: append([]int(nil), ids[g[0]:g[1]]...)}
+		for _, id := range leaf.entries {
+			leaf.box.extend(entries[id].box)
+		}
+		leaves = append(leaves, leaf)
+	}
+
+	return leaves
+}
+
+func packNodes(children []*rnode) []*rnode {
+	boxes := func(i int) Box { return children[i].box }
+	groups := tile(len(children), boxes, func(i, j int) { children[i], children[j] = children[j], children[i] })
+
+	parents := make([]*rnode, 0, len(groups))
+	for _, g := range groups {
+		parent := &rnode{box: emptyBox(), children: append([]*rnode(nil), children[g[0]:g[1]]...)}
+		for _, child := range parent.children {
+			parent.box.extend(child.box)
+		}
+		parents = append(parents, parent)
+	}
+
+	return parents
+}
+
+// tile sorts n boxes into vertical slabs along x, then y, then z and returns the
+// [start, end) ranges of each group of at most nodeCapacity boxes.
+func tile(n int, box func(int) Box, swap func(i, j int)) [][2]int {
+	leaves := int(math.Ceil(float64(n) / nodeCapacity))
+	slices := int(math.Ceil(math.Cbrt(float64(leaves))))
+	slabX := slices * slices * nodeCapacity
+	slabY := slices * nodeCapacity
+
+	sortRange(0, n, 0, box, swap)
+
+	var groups [][2]int
+	for x := 0; x < n; x += slabX {
+		xEnd := min(x+slabX, n)
+		sortRange(x, xEnd, 1, box, swap)
+		for y := x; y < xEnd; y += slabY {
+			yEnd := min(y+slabY, xEnd)
+			sortRange(y, yEnd, 2, box, swap)
+			for z := y; z < yEnd; z += nodeCapacity {
+				groups = append(groups, [2]int{z, min(z+nodeCapacity, yEnd)})
+			}
+		}
+	}
+
+	return groups
+}
+
+func sortRange(start, end, axis int, box func(int) Box, swap func(i, j int)) {
+	sort.Sort(axisSorter{start: start, end: end, axis: axis, box: box, swap: swap})
+}
+
+type axisSorter struct {
+	start, end, axis int
+	box              func(int) Box
+	swap             func(i, j int)
+}
+
+func (s axisSorter) Len() int { return s.end - s.start }
+func (s axisSorter) Less(i, j int) bool {
+	return s.box(s.start+i).center(s.axis) < s.box(s.start+j).center(s.axis)
+}
+func (s axisSorter) Swap(i, j int) { s.swap(s.start+i, s.start+j) }
+
+// search calls visit for every entry whose box intersects the query box.
+func (n *rnode) search(query Box, entries []entry, visit func(int)) {
+	if !n.box.Intersects(query) {
+		return
+	}
+	for _, id := range n.entries {
+		if entries[id].box.Intersects(query) {
+			visit(id)
+		}
+	}
+	for _, child := range n.children {
+		child.search(query, entries, visit)
+	}
+}
+
+// nearest walks the tree best-first and calls visit with entries in ascending distance
+// until visit returns false.
+func (n *rnode) nearest(p Point, entries []entry, visit func(id int, distance float64) bool) {
+	queue := &candidateQueue{{node: n, entry: -1, distance: n.box.distance(p)}}
+	for queue.Len() > 0 {
+		c := heap.Pop(queue).(candidate)
+		if c.node == nil {
+			if !visit(c.entry, c.distance) {
+				return
+			}
+			continue
+		}
+		for _, id := range c.node.entries {
+			e := entries[id]
+			heap.Push(queue, candidate{entry: id, distance: SegmentDistance(p, e.a, e.b)})
+		}
+		for _, child := range c.node.children {
+			heap.Push(queue, candidate{node: child, entry: -1, distance: child.box.distance(p)})
+		}
+	}
+}
+
+// candidate is either a tree node (bounded by its box distance) or an entry (exact distance).
+type candidate struct {
+	node     *rnode
+	entry    int
+	distance float64
+}
+
+type candidateQueue []candidate
+
+func (q candidateQueue) Len() int           { return len(q) }
+func (q candidateQueue) Less(i, j int) bool { return q[i].distance < q[j].distance }
+func (q candidateQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *candidateQueue) Push(x any)        { *q = append(*q, x.(candidate)) }
+func (q *candidateQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
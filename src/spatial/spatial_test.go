@@ -0,0 +1,60 @@
+// Package spatial
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-03
+package spatial
+
+import (
+	"testing"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+)
+
+func testIndex() *Index {
+	var nodes gisapi.NodesData
+	for i := 0; i < 100; i++ {
+		nodes = append(nodes, gisapi.NodeGeometry{ID: i, Geometry: []float64{float64(i % 10), float64(i / 10), 0}})
+	}
+	links := gisapi.LinksData{
+		// Crosses the box of the bbox test without any vertex inside it.
+		{ID: 500, Geometry: [][]float64{{-5, 2.5, 0}, {20, 2.5, 0}}},
+		{ID: 501, Geometry: [][]float64{{0, 0, 5}, {0, 0, 6}, {0, 0, 9}}},
+	}
+
+	return New(nodes, links)
+}
+
+func TestInBox(t *testing.T) {
+	result := testIndex().InBox(Box{Min: Point{1.5, 1.5, -1}, Max: Point{3.5, 3.5, 1}})
+	if len(result.Nodes) != 4 {
+		t.Errorf("Expected 4 nodes, got %d", len(result.Nodes))
+	}
+	if len(result.Links) != 1 || result.Links[0].ID != 500 {
+		t.Errorf("Expected link 500, got %+v", result.Links)
+	}
+}
+
+func TestInSphere(t *testing.T) {
+	result := testIndex().InSphere(Point{5, 5, 0}, 1, KindNode)
+	if len(result.Nodes) != 5 || len(result.Links) != 0 {
+		t.Errorf("Expected 5 nodes and no links, got %d and %d", len(result.Nodes), len(result.Links))
+	}
+}
+
+func TestNearest(t *testing.T) {
+	idx := testIndex()
+
+	result := idx.Nearest(Point{0.1, 0, 7}, 1, 0, KindLink)
+	if len(result.Hits) != 1 || result.Hits[0].ID != 501 || result.Hits[0].Segment != 1 {
+		t.Fatalf("Expected the second segment of link 501, got %+v", result.Hits)
+	}
+
+	result = idx.Nearest(Point{4.2, 7.1, 0}, 3, 0, KindNode)
+	if len(result.Hits) != 3 || result.Hits[0].ID != 74 {
+		t.Fatalf("Expected node 74 first, got %+v", result.Hits)
+	}
+	for i := 1; i < len(result.Hits); i++ {
+		if result.Hits[i].Distance < result.Hits[i-1].Distance {
+			t.Errorf("Hits are not sorted by distance: %+v", result.Hits)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+// Package tiles
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-04
+package tiles
+
+import "errors"
+
+var (
+	ErrTileNotFound = errors.New("tile does not exist")
+)
@@ -0,0 +1,48 @@
+// Package tiles
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-04
+package tiles
+
+import (
+	"github.com/teocci/go-hynix-3d-viewer/src/spatial"
+)
+
+// Simplify reduces a polyline with the Douglas-Peucker algorithm in 3D.
+// Points closer than tolerance to the simplified line are dropped; both ends are always kept.
+func Simplify(points [][]float64, tolerance float64) [][]float64 {
+	if len(points) < 3 || tolerance <= 0 {
+		return points
+	}
+
+	keep := make([]bool, len(points))
+	keep[0], keep[len(points)-1] = true, true
+
+	stack := [][2]int{{0, len(points) - 1}}
+	for len(stack) > 0 {
+		span := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		a, b := spatial.PointFrom(points[span[0]]), spatial.PointFrom(points[span[1]])
+		farthest, maxDistance := -1, tolerance
+		for i := span[0] + 1; i < span[1]; i++ {
+			if d := spatial.SegmentDistance(spatial.PointFrom(points[i]), a, b); d > maxDistance {
+				farthest, maxDistance = i, d
+			}
+		}
+		if farthest < 0 {
+			continue
+		}
+
+		keep[farthest] = true
+		stack = append(stack, [2]int{span[0], farthest}, [2]int{farthest, span[1]})
+	}
+
+	simplified := make([][]float64, 0)
+	for i, k := range keep {
+		if k {
+			simplified = append(simplified, points[i])
+		}
+	}
+
+	return simplified
+}
@@ -0,0 +1,119 @@
+// Package tiles
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-04
+package tiles
+
+import (
+	"testing"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+)
+
+func TestSimplify(t *testing.T) {
+	points := [][]float64{{0, 0, 0}, {1, 0.01, 0}, {2, 0, 0}, {3, 5, 0}, {4, 0, 0}}
+
+	got := Simplify(points, 0.1)
+	if len(got) != 4 || got[1][0] != 2 {
+		t.Errorf("Expected the near-collinear point to be dropped, got %v", got)
+	}
+	if len(Simplify(points, 0)) != len(points) {
+		t.Errorf("Expected a zero tolerance to keep every point")
+	}
+}
+
+func testNetwork() (gisapi.NodesData, gisapi.LinksData) {
+	var nodes gisapi.NodesData
+	var links gisapi.LinksData
+	for i := 0; i < 64; i++ {
+		x, y := float64(i%8), float64(i/8)
+		nodes = append(nodes, gisapi.NodeGeometry{ID: i, Geometry: []float64{x, y, 0}})
+		links = append(links, gisapi.LinkGeometry{ID: 100 + i, Geometry: [][]float64{{x, y, 0}, {x + 0.1, y, 0}, {x + 0.2, y, 0}}})
+	}
+	// Links too short to show at the coarse levels.
+	for i := 0; i < 64; i++ {
+		x, y := float64(i%8), float64(i/8)
+		links = append(links, gisapi.LinkGeometry{ID: 200 + i, Geometry: [][]float64{{x, y + 0.5, 0}, {x + 0.01, y + 0.5, 0}}})
+	}
+
+	return nodes, links
+}
+
+func TestBuild(t *testing.T) {
+	nodes, links := testNetwork()
+	ts := Build(nodes, links, Options{LeafCapacity: 12, MaxLevel: 5})
+	if ts.Depth() != 2 {
+		t.Fatalf("Expected depth 2, got %d", ts.Depth())
+	}
+
+	manifest := ts.Manifest()
+	linkCounts := make([]int, ts.Depth()+1)
+	nodeCount := 0
+	for _, info := range manifest.Tiles {
+		linkCounts[info.Level] += info.Links
+		if info.Level == ts.Depth() {
+			nodeCount += info.Nodes
+		}
+	}
+	if nodeCount != 64 || linkCounts[ts.Depth()] != 128 {
+		t.Errorf("Expected every element in the deepest level, got %d nodes and %d links", nodeCount, linkCounts[ts.Depth()])
+	}
+	for level := 0; level < ts.Depth(); level++ {
+		if linkCounts[level] != 64 {
+			t.Errorf("Expected the short links left out of level %d, got %d links", level, linkCounts[level])
+		}
+	}
+	if manifest.Tiles[0].Level != 0 {
+		t.Errorf("Expected the root tile first, got %+v", manifest.Tiles[0])
+	}
+}
+
+func TestBuildDefaults(t *testing.T) {
+	var nodes gisapi.NodesData
+	for i := 0; i < 4*DefaultLeafCapacity; i++ {
+		nodes = append(nodes, gisapi.NodeGeometry{ID: i, Geometry: []float64{float64(i % 200), float64(i / 200), 0}})
+	}
+
+	ts := Build(nodes, nil, Options{})
+	if ts.Depth() == 0 || len(ts.Manifest().Tiles) == 1 {
+		t.Errorf("Expected the default options to split a large network, got depth %d", ts.Depth())
+	}
+}
+
+func TestTile(t *testing.T) {
+	nodes, links := testNetwork()
+	ts := Build(nodes, links, Options{LeafCapacity: 12, MaxLevel: 5})
+
+	root, err := ts.Tile(Address{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(root.Links) != 64 || len(root.Links[0].Geometry) != 2 {
+		t.Errorf("Expected the long links simplified at the root, got %d links", len(root.Links))
+	}
+
+	leaf, err := ts.Tile(Address{Level: 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(leaf.Links[0].Geometry) != 3 || len(leaf.Clusters) != 0 {
+		t.Errorf("Expected the original geometry at the deepest level, got %+v", leaf)
+	}
+
+	if _, err := ts.Tile(Address{Level: 3}); err != ErrTileNotFound {
+		t.Errorf("Expected ErrTileNotFound, got %v", err)
+	}
+}
+
+func TestBuildClustersCoarseNodes(t *testing.T) {
+	var nodes gisapi.NodesData
+	for i := 0; i < 400; i++ {
+		// Twenty tight clumps spread along the x axis.
+		nodes = append(nodes, gisapi.NodeGeometry{ID: i, Geometry: []float64{float64(i/20) * 100, float64(i%20) * 0.001, 0}})
+	}
+
+	ts := Build(nodes, nil, Options{LeafCapacity: 50})
+	root := ts.Manifest().Tiles[0]
+	if root.Level != 0 || root.Nodes >= len(nodes) {
+		t.Errorf("Expected the root to hold fewer entries than nodes, got %+v", root)
+	}
+}
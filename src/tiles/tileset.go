@@ -0,0 +1,406 @@
+// Package tiles
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-04
+package tiles
+
+import (
+	"math"
+	"sort"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+	"github.com/teocci/go-hynix-3d-viewer/src/spatial"
+)
+
+const (
+	DefaultLeafCapacity = 5000
+	DefaultMaxLevel     = 8
+
+	// simplifyDivisions is how many tolerance steps fit along the edge of a coarse tile.
+	simplifyDivisions = 256
+)
+
+// Options tune how deep the octree goes.
+// The tree stops splitting when no tile holds more than LeafCapacity elements or at MaxLevel.
+// Zero values pick the defaults.
+type Options struct {
+	LeafCapacity int
+	MaxLevel     int
+}
+
+// Address locates a tile: level 0 is the whole network and every level halves the tile edge.
+type Address struct {
+	Level int `json:"level"`
+	X     int `json:"x"`
+	Y     int `json:"y"`
+	Z     int `json:"z"`
+}
+
+// TileInfo describes a non-empty tile in the manifest.
+// GeometricError is the simplification tolerance of the tile; it is zero at the deepest level.
+// Nodes counts the nodes and clusters the tile carries and Links the links long enough to
+// show at its tolerance, so coarse tiles stay smaller than the levels under them.
+type TileInfo struct {
+	Address
+	Box            spatial.Box `json:"box"`
+	GeometricError float64     `json:"geometricError"`
+	Nodes          int         `json:"nodes"`
+	Links          int         `json:"links"`
+}
+
+// Manifest lists every non-empty tile, coarse levels first.
+type Manifest struct {
+	Bounds spatial.Box `json:"bounds"`
+	Depth  int         `json:"depth"`
+	Nodes  int         `json:"nodes"`
+	Links  int         `json:"links"`
+	Tiles  []TileInfo  `json:"tiles"`
+}
+
+// Cluster stands for several nodes merged at a coarse level.
+type Cluster struct {
+	Geometry []float64 `json:"geometry"`
+	Count    int       `json:"count"`
+}
+
+// Tile is the content of a tile. Coarse tiles carry simplified link polylines and
+// clustered nodes, leaving out links shorter than their tolerance; tiles at the deepest
+// level carry the original geometry.
+type Tile struct {
+	TileInfo
+	Nodes    gisapi.NodesData `json:"nodes"`
+	Clusters []Cluster        `json:"clusters"`
+	Links    gisapi.LinksData `json:"links"`
+}
+
+// Tileset partitions a network into an octree. Every node and link belongs to the
+// tile containing its anchor: the node position or the center of the link bounds.
+type Tileset struct {
+	nodes  gisapi.NodesData
+	links  gisapi.LinksData
+	origin spatial.Point
+	edge   float64
+	depth  int
+	// members holds, per level, the node and link indexes of each tile.
+	members []tileMap
+}
+
+type members struct {
+	nodes []int
+	links []int
+	// entries is the number of nodes and clusters left once the nodes are clustered.
+	entries int
+}
+
+type anchor struct {
+	index int
+	point spatial.Point
+}
+
+// Build partitions the network.
+func Build(nodes gisapi.NodesData, links gisapi.LinksData, opts Options) *Tileset {
+	if opts.LeafCapacity <= 0 {
+		opts.LeafCapacity = DefaultLeafCapacity
+	}
+	if opts.MaxLevel <= 0 || opts.MaxLevel > DefaultMaxLevel*2 {
+		opts.MaxLevel = DefaultMaxLevel
+	}
+
+	ts := &Tileset{nodes: nodes, links: links}
+
+	var nodeAnchors, linkAnchors []anchor
+	extents := make([]float64, len(links))
+	bounds := emptyBox()
+	for i, n := range nodes {
+		if len(n.Geometry) == 0 {
+			continue
+		}
+		p := spatial.PointFrom(n.Geometry)
+		nodeAnchors = append(nodeAnchors, anchor{i, p})
+		extend(&bounds, p)
+	}
+	for i, l := range links {
+		if len(l.Geometry) == 0 {
+			continue
+		}
+		box := emptyBox()
+		for _, p := range l.Geometry {
+			extend(&box, spatial.PointFrom(p))
+		}
+		linkAnchors = append(linkAnchors, anchor{i, center(box)})
+		for a := 0; a < 3; a++ {
+			extents[i] = math.Max(extents[i], box.Max[a]-box.Min[a])
+		}
+		extend(&bounds, box.Min)
+		extend(&bounds, box.Max)
+	}
+	if math.IsInf(bounds.Min[0], 1) {
+		bounds = spatial.Box{}
+	}
+
+	ts.origin = bounds.Min
+	for i := 0; i < 3; i++ {
+		ts.edge = math.Max(ts.edge, bounds.Max[i]-bounds.Min[i])
+	}
+	if ts.edge == 0 {
+		ts.edge = 1
+	}
+
+	for level := 0; ; level++ {
+		tiles := tileMap{}
+		largest := 0
+		for _, a := range nodeAnchors {
+			m := tiles.member(ts.address(level, a.point))
+			m.nodes = append(m.nodes, a.index)
+			largest = max(largest, len(m.nodes)+len(m.links))
+		}
+		for _, a := range linkAnchors {
+			m := tiles.member(ts.address(level, a.point))
+			m.links = append(m.links, a.index)
+			largest = max(largest, len(m.nodes)+len(m.links))
+		}
+		ts.members = append(ts.members, tiles)
+
+		if largest <= opts.LeafCapacity || level >= opts.MaxLevel {
+			ts.depth = level
+			break
+		}
+	}
+	ts.thin(extents)
+
+	return ts
+}
+
+// thin leaves out of the coarse levels the links shorter than the tolerance of the level,
+// which would not show at that scale, and counts the nodes left once clustered.
+func (ts *Tileset) thin(extents []float64) {
+	for level, tiles := range ts.members {
+		tolerance := ts.geometricError(level)
+		for addr, m := range tiles {
+			if tolerance == 0 {
+				m.entries = len(m.nodes)
+				continue
+			}
+
+			links := m.links[:0]
+			for _, i := range m.links {
+				if extents[i] >= tolerance {
+					links = append(links, i)
+				}
+			}
+			m.links = links
+			m.entries = len(ts.cells(m.nodes, tolerance).order)
+			if m.entries == 0 && len(m.links) == 0 {
+				delete(tiles, addr)
+			}
+		}
+	}
+}
+
+// Depth returns the deepest level of the tree.
+func (ts *Tileset) Depth() int {
+	return ts.depth
+}
+
+// Manifest lists the non-empty tiles.
+func (ts *Tileset) Manifest() Manifest {
+	manifest := Manifest{
+		Bounds: ts.box(Address{}),
+		Depth:  ts.depth,
+		Nodes:  len(ts.nodes),
+		Links:  len(ts.links),
+		Tiles:  []TileInfo{},
+	}
+
+	for _, tiles := range ts.members {
+		start := len(manifest.Tiles)
+		for addr, m := range tiles {
+			manifest.Tiles = append(manifest.Tiles, ts.info(addr, m))
+		}
+		infos := manifest.Tiles[start:]
+		sort.Slice(infos, func(a, b int) bool {
+			return infos[a].Address.less(infos[b].Address)
+		})
+	}
+
+	return manifest
+}
+
+// Tile returns the content of the tile at the address.
+func (ts *Tileset) Tile(addr Address) (Tile, error) {
+	if addr.Level < 0 || addr.Level > ts.depth {
+		return Tile{}, ErrTileNotFound
+	}
+
+	m, ok := ts.members[addr.Level][addr]
+	if !ok {
+		return Tile{}, ErrTileNotFound
+	}
+
+	tile := Tile{
+		TileInfo: ts.info(addr, m),
+		Nodes:    make(gisapi.NodesData, 0, len(m.nodes)),
+		Clusters: []Cluster{},
+		Links:    make(gisapi.LinksData, 0, len(m.links)),
+	}
+
+	tolerance := tile.GeometricError
+	for _, i := range m.links {
+		link := ts.links[i]
+		link.Geometry = Simplify(link.Geometry, tolerance)
+		tile.Links = append(tile.Links, link)
+	}
+
+	if tolerance == 0 {
+		for _, i := range m.nodes {
+			tile.Nodes = append(tile.Nodes, ts.nodes[i])
+		}
+		return tile, nil
+	}
+
+	tile.Nodes, tile.Clusters = ts.cluster(m.nodes, tolerance)
+
+	return tile, nil
+}
+
+type cellKey [3]int64
+
+// grid groups node indexes by cell, keeping the cells in the order they were first met.
+type grid struct {
+	groups map[cellKey][]int
+	order  []cellKey
+}
+
+// cells puts nodes on a grid of the given cell size.
+func (ts *Tileset) cells(indexes []int, cell float64) grid {
+	g := grid{groups: map[cellKey][]int{}, order: make([]cellKey, 0)}
+	for _, i := range indexes {
+		p := spatial.PointFrom(ts.nodes[i].Geometry)
+		k := cellKey{}
+		for a := 0; a < 3; a++ {
+			k[a] = int64(math.Floor((p[a] - ts.origin[a]) / cell))
+		}
+		if _, ok := g.groups[k]; !ok {
+			g.order = append(g.order, k)
+		}
+		g.groups[k] = append(g.groups[k], i)
+	}
+
+	return g
+}
+
+// cluster groups nodes on a grid of the tolerance size. Lone nodes are kept as they are.
+func (ts *Tileset) cluster(indexes []int, cell float64) (gisapi.NodesData, []Cluster) {
+	g := ts.cells(indexes, cell)
+
+	nodes := make(gisapi.NodesData, 0)
+	clusters := make([]Cluster, 0)
+	for _, k := range g.order {
+		group := g.groups[k]
+		if len(group) == 1 {
+			nodes = append(nodes, ts.nodes[group[0]])
+			continue
+		}
+
+		var sum spatial.Point
+		for _, i := range group {
+			p := spatial.PointFrom(ts.nodes[i].Geometry)
+			for a := 0; a < 3; a++ {
+				sum[a] += p[a]
+			}
+		}
+		n := float64(len(group))
+		clusters = append(clusters, Cluster{Geometry: []float64{sum[0] / n, sum[1] / n, sum[2] / n}, Count: len(group)})
+	}
+
+	return nodes, clusters
+}
+
+func (ts *Tileset) info(addr Address, m *members) TileInfo {
+	return TileInfo{
+		Address:        addr,
+		Box:            ts.box(addr),
+		GeometricError: ts.geometricError(addr.Level),
+		Nodes:          m.entries,
+		Links:          len(m.links),
+	}
+}
+
+func (ts *Tileset) geometricError(level int) float64 {
+	if level >= ts.depth {
+		return 0
+	}
+
+	return ts.tileEdge(level) / simplifyDivisions
+}
+
+func (ts *Tileset) tileEdge(level int) float64 {
+	return ts.edge / float64(int(1)<<level)
+}
+
+func (ts *Tileset) box(addr Address) spatial.Box {
+	edge := ts.tileEdge(addr.Level)
+	cell := [3]int{addr.X, addr.Y, addr.Z}
+
+	var box spatial.Box
+	for a := 0; a < 3; a++ {
+		box.Min[a] = ts.origin[a] + float64(cell[a])*edge
+		box.Max[a] = box.Min[a] + edge
+	}
+
+	return box
+}
+
+// address returns the tile of the level containing p.
+func (ts *Tileset) address(level int, p spatial.Point) Address {
+	cells := 1 << level
+	var cell [3]int
+	for a := 0; a < 3; a++ {
+		c := int(math.Floor((p[a] - ts.origin[a]) / ts.edge * float64(cells)))
+		cell[a] = max(0, min(cells-1, c))
+	}
+
+	return Address{Level: level, X: cell[0], Y: cell[1], Z: cell[2]}
+}
+
+func (a Address) less(b Address) bool {
+	if a.X != b.X {
+		return a.X < b.X
+	}
+	if a.Y != b.Y {
+		return a.Y < b.Y
+	}
+
+	return a.Z < b.Z
+}
+
+type tileMap map[Address]*members
+
+func (t tileMap) member(addr Address) *members {
+	m, ok := t[addr]
+	if !ok {
+		m = &members{}
+		t[addr] = m
+	}
+
+	return m
+}
+
+func emptyBox() spatial.Box {
+	inf := math.Inf(1)
+	return spatial.Box{Min: spatial.Point{inf, inf, inf}, Max: spatial.Point{-inf, -inf, -inf}}
+}
+
+func extend(box *spatial.Box, p spatial.Point) {
+	for a := 0; a < 3; a++ {
+		box.Min[a] = math.Min(box.Min[a], p[a])
+		box.Max[a] = math.Max(box.Max[a], p[a])
+	}
+}
+
+func center(box spatial.Box) spatial.Point {
+	return spatial.Point{
+		(box.Min[0] + box.Max[0]) / 2,
+		(box.Min[1] + box.Max[1]) / 2,
+		(box.Min[2] + box.Max[2]) / 2,
+	}
+}
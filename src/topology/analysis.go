@@ -0,0 +1,227 @@
+// Package topology
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-01
+package topology
+
+import "sort"
+
+// Summary counts every problem the analysis can find.
+type Summary struct {
+	Nodes            int `json:"nodes"`
+	Links            int `json:"links"`
+	Components       int `json:"components"`
+	LargestComponent int `json:"largestComponent"`
+	IsolatedNodes    int `json:"isolatedNodes"`
+	DanglingLinks    int `json:"danglingLinks"`
+	DuplicateNodes   int `json:"duplicateNodes"`
+	Cycles           int `json:"cycles"`
+}
+
+// Component is a set of nodes reachable from each other.
+type Component struct {
+	NodeCount int   `json:"nodeCount"`
+	LinkCount int   `json:"linkCount"`
+	NodeIds   []int `json:"nodeIds"`
+}
+
+// DegreeCount is one bucket of the degree histogram.
+type DegreeCount struct {
+	Degree int `json:"degree"`
+	Nodes  int `json:"nodes"`
+}
+
+// Cycles lists independent cycles as the ids of their links.
+// Count is the cycle rank of the graph, which may exceed len(Cycles) when a limit applies.
+type Cycles struct {
+	Count  int     `json:"count"`
+	Cycles [][]int `json:"cycles"`
+}
+
+func (g *Graph) Summary() Summary {
+	components := g.Components()
+	largest := 0
+	if len(components) > 0 {
+		largest = components[0].NodeCount
+	}
+
+	return Summary{
+		Nodes:            g.NodeCount(),
+		Links:            g.LinkCount(),
+		Components:       len(components),
+		LargestComponent: largest,
+		IsolatedNodes:    len(g.IsolatedNodes()),
+		DanglingLinks:    len(g.dangling),
+		DuplicateNodes:   len(g.duplicates),
+		Cycles:           g.cycleRank(len(components)),
+	}
+}
+
+// Components returns the connected components, largest first.
+func (g *Graph) Components() []Component {
+	labels, count := g.label()
+
+	components := make([]Component, count)
+	for i, c := range labels {
+		components[c].NodeCount++
+		components[c].NodeIds = append(components[c].NodeIds, g.nodes[i].ID)
+	}
+	for _, ends := range g.ends {
+		components[labels[ends[0]]].LinkCount++
+	}
+
+	sort.SliceStable(components, func(a, b int) bool {
+		return components[a].NodeCount > components[b].NodeCount
+	})
+
+	return components
+}
+
+// IsolatedNodes returns the ids of nodes without any link.
+func (g *Graph) IsolatedNodes() []int {
+	isolated := make([]int, 0)
+	for i, edges := range g.adj {
+		if len(edges) == 0 {
+			isolated = append(isolated, g.nodes[i].ID)
+		}
+	}
+
+	return isolated
+}
+
+// DanglingLinks returns the links referencing missing nodes.
+func (g *Graph) DanglingLinks() []DanglingLink {
+	if g.dangling == nil {
+		return []DanglingLink{}
+	}
+
+	return g.dangling
+}
+
+// DuplicateNodes returns the node ids found more than once, with their number of occurrences.
+func (g *Graph) DuplicateNodes() []DuplicateNode {
+	if g.duplicates == nil {
+		return []DuplicateNode{}
+	}
+
+	return g.duplicates
+}
+
+// DegreeHistogram counts nodes by degree, in ascending degree order.
+func (g *Graph) DegreeHistogram() []DegreeCount {
+	counts := map[int]int{}
+	for i := range g.nodes {
+		counts[g.Degree(i)]++
+	}
+
+	histogram := make([]DegreeCount, 0, len(counts))
+	for degree, nodes := range counts {
+		histogram = append(histogram, DegreeCount{Degree: degree, Nodes: nodes})
+	}
+	sort.Slice(histogram, func(a, b int) bool {
+		return histogram[a].Degree < histogram[b].Degree
+	})
+
+	return histogram
+}
+
+// Cycles returns a cycle basis: one cycle per link left out of a BFS spanning forest.
+// At most limit cycles are listed; a limit of zero or less lists them all.
+func (g *Graph) Cycles(limit int) Cycles {
+	parent := make([]int, len(g.nodes))
+	parentLink := make([]int, len(g.nodes))
+	depth := make([]int, len(g.nodes))
+	for i := range parent {
+		parent[i] = -1
+	}
+
+	tree := make([]bool, len(g.links))
+	visited := make([]bool, len(g.nodes))
+	for root := range g.nodes {
+		if visited[root] {
+			continue
+		}
+		visited[root] = true
+		queue := []int{root}
+		for len(queue) > 0 {
+			n := queue[0]
+			queue = queue[1:]
+			for _, e := range g.adj[n] {
+				if visited[e.To] {
+					continue
+				}
+				visited[e.To] = true
+				tree[e.Link] = true
+				parent[e.To] = n
+				parentLink[e.To] = e.Link
+				depth[e.To] = depth[n] + 1
+				queue = append(queue, e.To)
+			}
+		}
+	}
+
+	result := Cycles{Cycles: [][]int{}}
+	for li, inTree := range tree {
+		if inTree {
+			continue
+		}
+		result.Count++
+		if limit > 0 && len(result.Cycles) >= limit {
+			continue
+		}
+
+		// Walk both ends up to their lowest common ancestor.
+		a, b := g.ends[li][0], g.ends[li][1]
+		left := []int{g.links[li].ID}
+		var right []int
+		for a != b {
+			if depth[a] >= depth[b] {
+				left = append(left, g.links[parentLink[a]].ID)
+				a = parent[a]
+			} else {
+				right = append(right, g.links[parentLink[b]].ID)
+				b = parent[b]
+			}
+		}
+		for i := len(right) - 1; i >= 0; i-- {
+			left = append(left, right[i])
+		}
+		result.Cycles = append(result.Cycles, left)
+	}
+
+	return result
+}
+
+// label assigns a component number to every node.
+func (g *Graph) label() ([]int, int) {
+	labels := make([]int, len(g.nodes))
+	for i := range labels {
+		labels[i] = -1
+	}
+
+	count := 0
+	for root := range g.nodes {
+		if labels[root] >= 0 {
+			continue
+		}
+		labels[root] = count
+		stack := []int{root}
+		for len(stack) > 0 {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, e := range g.adj[n] {
+				if labels[e.To] < 0 {
+					labels[e.To] = count
+					stack = append(stack, e.To)
+				}
+			}
+		}
+		count++
+	}
+
+	return labels, count
+}
+
+// cycleRank is the number of independent cycles: links - nodes + components.
+func (g *Graph) cycleRank(components int) int {
+	return len(g.links) - len(g.nodes) + components
+}
@@ -0,0 +1,11 @@
+// Package topology
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-02
+package topology
+
+import "errors"
+
+var (
+	ErrNodeNotFound = errors.New("node does not exist in the network")
+	ErrNoPath       = errors.New("nodes are not connected")
+)
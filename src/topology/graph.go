@@ -0,0 +1,118 @@
+// Package topology
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-01
+package topology
+
+import "github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+
+// Edge is one direction of a link in the adjacency list.
+type Edge struct {
+	Link int // index into Graph.links
+	To   int // index of the node at the other end
+}
+
+// Graph is an undirected multigraph of network nodes connected by links.
+// Links whose start or end node does not exist are kept apart as dangling links,
+// and node ids that appear more than once are recorded as duplicates.
+type Graph struct {
+	nodes      []gisapi.NodeGeometry
+	index      map[int]int
+	links      []gisapi.LinkGeometry
+	ends       [][2]int
+	adj        [][]Edge
+	dangling   []DanglingLink
+	duplicates []DuplicateNode
+}
+
+// DanglingLink is a link that references a node id missing from the network.
+type DanglingLink struct {
+	LinkId       int  `json:"linkId"`
+	StartNodeId  int  `json:"startNodeId"`
+	EndNodeId    int  `json:"endNodeId"`
+	MissingStart bool `json:"missingStart"`
+	MissingEnd   bool `json:"missingEnd"`
+}
+
+// DuplicateNode is a node id shared by several nodes of the network.
+type DuplicateNode struct {
+	NodeId int `json:"nodeId"`
+	Count  int `json:"count"`
+}
+
+// New builds the graph. Duplicate node ids keep their first occurrence and are recorded.
+func New(nodes gisapi.NodesData, links gisapi.LinksData) *Graph {
+	g := &Graph{
+		nodes: make([]gisapi.NodeGeometry, 0, len(nodes)),
+		index: make(map[int]int, len(nodes)),
+	}
+
+	duplicates := map[int]int{}
+	for _, n := range nodes {
+		if _, ok := g.index[n.ID]; ok {
+			d, ok := duplicates[n.ID]
+			if !ok {
+				d = len(g.duplicates)
+				duplicates[n.ID] = d
+				g.duplicates = append(g.duplicates, DuplicateNode{NodeId: n.ID, Count: 1})
+			}
+			g.duplicates[d].Count++
+			continue
+		}
+		g.index[n.ID] = len(g.nodes)
+		g.nodes = append(g.nodes, n)
+	}
+	g.adj = make([][]Edge, len(g.nodes))
+
+	for _, l := range links {
+		s, okStart := g.index[l.StartNodeId]
+		e, okEnd := g.index[l.EndNodeId]
+		if !okStart || !okEnd {
+			g.dangling = append(g.dangling, DanglingLink{
+				LinkId:       l.ID,
+				StartNodeId:  l.StartNodeId,
+				EndNodeId:    l.EndNodeId,
+				MissingStart: !okStart,
+				MissingEnd:   !okEnd,
+			})
+			continue
+		}
+
+		li := len(g.links)
+		g.links = append(g.links, l)
+		g.ends = append(g.ends, [2]int{s, e})
+		g.adj[s] = append(g.adj[s], Edge{Link: li, To: e})
+		if s != e {
+			g.adj[e] = append(g.adj[e], Edge{Link: li, To: s})
+		}
+	}
+
+	return g
+}
+
+// NodeCount returns the number of distinct nodes.
+func (g *Graph) NodeCount() int {
+	return len(g.nodes)
+}
+
+// LinkCount returns the number of links connecting existing nodes.
+func (g *Graph) LinkCount() int {
+	return len(g.links)
+}
+
+// HasNode reports whether the node id exists.
+func (g *Graph) HasNode(id int) bool {
+	_, ok := g.index[id]
+	return ok
+}
+
+// Degree returns the number of link ends at a node. A self-loop counts twice.
+func (g *Graph) Degree(i int) int {
+	d := len(g.adj[i])
+	for _, e := range g.adj[i] {
+		if e.To == i {
+			d++
+		}
+	}
+
+	return d
+}
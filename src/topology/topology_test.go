@@ -0,0 +1,124 @@
+// Package topology
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-01
+package topology
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+)
+
+func testGraph() *Graph {
+	// Node 5 is listed twice.
+	nodes := gisapi.NodesData{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}, {ID: 6}, {ID: 7}, {ID: 5}}
+	links := gisapi.LinksData{
+		// A triangle 1-2-3 with a tail to 4.
+		{ID: 10, StartNodeId: 1, EndNodeId: 2},
+		{ID: 11, StartNodeId: 2, EndNodeId: 3},
+		{ID: 12, StartNodeId: 3, EndNodeId: 1},
+		{ID: 13, StartNodeId: 3, EndNodeId: 4},
+		// A separate pair 5-6; 7 is isolated.
+		{ID: 14, StartNodeId: 5, EndNodeId: 6},
+		// Dangling.
+		{ID: 15, StartNodeId: 6, EndNodeId: 99},
+	}
+
+	return New(nodes, links)
+}
+
+func TestSummary(t *testing.T) {
+	want := Summary{Nodes: 7, Links: 5, Components: 3, LargestComponent: 4, IsolatedNodes: 1, DanglingLinks: 1, DuplicateNodes: 1, Cycles: 1}
+	if got := testGraph().Summary(); got != want {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+}
+
+func TestDuplicateNodes(t *testing.T) {
+	want := []DuplicateNode{{NodeId: 5, Count: 2}}
+	if got := testGraph().DuplicateNodes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestCycles(t *testing.T) {
+	cycles := testGraph().Cycles(0)
+	if cycles.Count != 1 || len(cycles.Cycles) != 1 || len(cycles.Cycles[0]) != 3 {
+		t.Fatalf("Expected the triangle, got %+v", cycles)
+	}
+
+	seen := map[int]bool{}
+	for _, id := range cycles.Cycles[0] {
+		seen[id] = true
+	}
+	if !seen[10] || !seen[11] || !seen[12] {
+		t.Errorf("Expected links 10, 11 and 12, got %v", cycles.Cycles[0])
+	}
+}
+
+func TestDegreeHistogram(t *testing.T) {
+	want := []DegreeCount{{0, 1}, {1, 3}, {2, 2}, {3, 1}}
+	got := testGraph().DegreeHistogram()
+	if len(got) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	}
+}
+
+func line(points ...[]float64) [][]float64 {
+	return points
+}
+
+func TestShortestPath(t *testing.T) {
+	nodes := gisapi.NodesData{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+	links := gisapi.LinksData{
+		// The direct link 1-4 is longer than the detour through 2 and 3.
+		{ID: 10, StartNodeId: 1, EndNodeId: 4, Geometry: line([]float64{0, 0, 0}, []float64{0, 0, 10})},
+		{ID: 11, StartNodeId: 1, EndNodeId: 2, Geometry: line([]float64{0, 0, 0}, []float64{0, 3, 0})},
+		{ID: 12, StartNodeId: 3, EndNodeId: 2, Geometry: line([]float64{0, 0, 0}, []float64{0, 0, 3})},
+		{ID: 13, StartNodeId: 3, EndNodeId: 4, Geometry: line([]float64{0, 0, 0}, []float64{2, 0, 0})},
+	}
+
+	path, err := New(nodes, links).ShortestPath(1, 4)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if path.Length != 8 || !reflect.DeepEqual(path.NodeIds, []int{1, 2, 3, 4}) || !reflect.DeepEqual(path.LinkIds, []int{11, 12, 13}) {
+		t.Errorf("Expected the detour of length 8, got %+v", path)
+	}
+
+	if _, err := testGraph().ShortestPath(1, 5); !errors.Is(err, ErrNoPath) {
+		t.Errorf("Expected ErrNoPath, got %v", err)
+	}
+}
+
+func TestTrace(t *testing.T) {
+	nodes := gisapi.NodesData{{ID: 1}, {ID: 2, Type: 9}, {ID: 3}, {ID: 4}}
+	links := gisapi.LinksData{
+		{ID: 10, StartNodeId: 1, EndNodeId: 2},
+		{ID: 11, StartNodeId: 2, EndNodeId: 3},
+		{ID: 12, StartNodeId: 4, EndNodeId: 1},
+	}
+	g := New(nodes, links)
+
+	down, _ := g.Trace(1, Downstream, nil)
+	if !reflect.DeepEqual(down.NodeIds, []int{1, 2, 3}) || !reflect.DeepEqual(down.LinkIds, []int{10, 11}) {
+		t.Errorf("Expected downstream 1, 2, 3, got %+v", down)
+	}
+
+	up, _ := g.Trace(1, Upstream, nil)
+	if !reflect.DeepEqual(up.NodeIds, []int{1, 4}) {
+		t.Errorf("Expected upstream 1, 4, got %+v", up)
+	}
+
+	stopped, _ := g.Trace(1, Downstream, map[int]bool{9: true})
+	if !reflect.DeepEqual(stopped.NodeIds, []int{1, 2}) || !reflect.DeepEqual(stopped.Barriers, []int{2}) {
+		t.Errorf("Expected the trace to stop at node 2, got %+v", stopped)
+	}
+}
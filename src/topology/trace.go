@@ -0,0 +1,199 @@
+// Package topology
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-02
+package topology
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+)
+
+// Direction selects which link ends a trace may follow.
+// Flow runs from the start node of a link to its end node.
+type Direction string
+
+const (
+	Downstream Direction = "downstream"
+	Upstream   Direction = "upstream"
+	Both       Direction = "both"
+)
+
+// Path is the shortest route between two nodes, listed from the first node to the last.
+type Path struct {
+	Length  float64 `json:"length"`
+	NodeIds []int   `json:"nodeIds"`
+	LinkIds []int   `json:"linkIds"`
+}
+
+// Trace is the set of nodes and links reached from a start node.
+// Barriers lists the barrier nodes the trace stopped at.
+type Trace struct {
+	NodeIds  []int `json:"nodeIds"`
+	LinkIds  []int `json:"linkIds"`
+	Barriers []int `json:"barriers"`
+}
+
+// LinkLength returns the 3D length of the link polyline.
+func LinkLength(l gisapi.LinkGeometry) float64 {
+	length := 0.0
+	for i := 1; i < len(l.Geometry); i++ {
+		length += distance(l.Geometry[i-1], l.Geometry[i])
+	}
+
+	return length
+}
+
+// ShortestPath finds the route between two node ids with the smallest total link length.
+// Links are followed in both directions.
+func (g *Graph) ShortestPath(from, to int) (Path, error) {
+	src, ok := g.index[from]
+	if !ok {
+		return Path{}, ErrNodeNotFound
+	}
+	dst, ok := g.index[to]
+	if !ok {
+		return Path{}, ErrNodeNotFound
+	}
+
+	dist := make([]float64, len(g.nodes))
+	via := make([]Edge, len(g.nodes))
+	for i := range dist {
+		dist[i] = math.Inf(1)
+		via[i] = Edge{Link: -1, To: -1}
+	}
+	dist[src] = 0
+
+	lengths := g.linkLengths()
+	queue := &distanceQueue{{node: src}}
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(queued)
+		if item.dist > dist[item.node] {
+			continue
+		}
+		if item.node == dst {
+			break
+		}
+		for _, e := range g.adj[item.node] {
+			d := item.dist + lengths[e.Link]
+			if d < dist[e.To] {
+				dist[e.To] = d
+				via[e.To] = Edge{Link: e.Link, To: item.node}
+				heap.Push(queue, queued{node: e.To, dist: d})
+			}
+		}
+	}
+
+	if math.IsInf(dist[dst], 1) {
+		return Path{}, ErrNoPath
+	}
+
+	// Walk back from the destination, then reverse.
+	path := Path{Length: dist[dst], NodeIds: []int{g.nodes[dst].ID}, LinkIds: []int{}}
+	for n := dst; n != src; n = via[n].To {
+		path.LinkIds = append(path.LinkIds, g.links[via[n].Link].ID)
+		path.NodeIds = append(path.NodeIds, g.nodes[via[n].To].ID)
+	}
+	reverse(path.NodeIds)
+	reverse(path.LinkIds)
+
+	return path, nil
+}
+
+// Trace collects every node and link reachable from start in the given direction.
+// Nodes whose type is in barriers are included but not passed through, except the start node.
+func (g *Graph) Trace(start int, direction Direction, barriers map[int]bool) (Trace, error) {
+	root, ok := g.index[start]
+	if !ok {
+		return Trace{}, ErrNodeNotFound
+	}
+
+	result := Trace{NodeIds: []int{start}, LinkIds: []int{}, Barriers: []int{}}
+	visited := make([]bool, len(g.nodes))
+	walked := make([]bool, len(g.links))
+	visited[root] = true
+
+	queue := []int{root}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for _, e := range g.adj[n] {
+			if walked[e.Link] || !g.follows(e, n, direction) {
+				continue
+			}
+			walked[e.Link] = true
+			result.LinkIds = append(result.LinkIds, g.links[e.Link].ID)
+
+			if visited[e.To] {
+				continue
+			}
+			visited[e.To] = true
+			result.NodeIds = append(result.NodeIds, g.nodes[e.To].ID)
+			if barriers[g.nodes[e.To].Type] {
+				result.Barriers = append(result.Barriers, g.nodes[e.To].ID)
+				continue
+			}
+			queue = append(queue, e.To)
+		}
+	}
+
+	return result, nil
+}
+
+// follows reports whether the edge leaving node n may be walked in the given direction.
+func (g *Graph) follows(e Edge, n int, direction Direction) bool {
+	ends := g.ends[e.Link]
+	switch direction {
+	case Downstream:
+		return ends[0] == n
+	case Upstream:
+		return ends[1] == n
+	default:
+		return true
+	}
+}
+
+func (g *Graph) linkLengths() []float64 {
+	lengths := make([]float64, len(g.links))
+	for i, l := range g.links {
+		lengths[i] = LinkLength(l)
+	}
+
+	return lengths
+}
+
+func distance(a, b []float64) float64 {
+	sum := 0.0
+	for i := 0; i < len(a) && i < len(b) && i < 3; i++ {
+		d := a[i] - b[i]
+		sum += d * d
+	}
+
+	return math.Sqrt(sum)
+}
+
+func reverse(ids []int) {
+	for i, j := 0, len(ids)-1; i < j; i, j = i+1, j-1 {
+		ids[i], ids[j] = ids[j], ids[i]
+	}
+}
+
+type queued struct {
+	node int
+	dist float64
+}
+
+// distanceQueue is a min-heap of nodes by tentative distance.
+type distanceQueue []queued
+
+func (q distanceQueue) Len() int           { return len(q) }
+func (q distanceQueue) Less(i, j int) bool { return q[i].dist < q[j].dist }
+func (q distanceQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *distanceQueue) Push(x any)        { *q = append(*q, x.(queued)) }
+func (q *distanceQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,10 @@
+// Package validator
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-27
+package validator
+
+import "errors"
+
+var (
+	ErrInvalidGISData = errors.New("invalid GIS data")
+)
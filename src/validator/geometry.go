@@ -0,0 +1,71 @@
+// Package validator
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-27
+package validator
+
+// ring returns the polygon vertices without consecutive duplicates or an explicit closing vertex.
+// Vertices are projected onto the XY plane, which is the plane the viewer extrudes polygons from.
+func ring(vertices [][]float64) [][2]float64 {
+	out := make([][2]float64, 0, len(vertices))
+	for _, v := range vertices {
+		if len(v) < 2 {
+			continue
+		}
+		p := [2]float64{v[0], v[1]}
+		if len(out) > 0 && out[len(out)-1] == p {
+			continue
+		}
+		out = append(out, p)
+	}
+	if len(out) > 1 && out[0] == out[len(out)-1] {
+		out = out[:len(out)-1]
+	}
+
+	return out
+}
+
+// selfIntersection returns the indexes of the first pair of non-adjacent ring edges that touch,
+// or -1, -1 when the ring is simple. Edge i goes from r[i] to r[i+1].
+func selfIntersection(r [][2]float64) (int, int) {
+	n := len(r)
+	for i := 0; i < n; i++ {
+		a1, a2 := r[i], r[(i+1)%n]
+		for j := i + 1; j < n; j++ {
+			if j == i+1 || (i == 0 && j == n-1) {
+				continue
+			}
+			b1, b2 := r[j], r[(j+1)%n]
+			if segmentsIntersect(a1, a2, b1, b2) {
+				return i, j
+			}
+		}
+	}
+
+	return -1, -1
+}
+
+func segmentsIntersect(p1, p2, q1, q2 [2]float64) bool {
+	d1 := orientation(q1, q2, p1)
+	d2 := orientation(q1, q2, p2)
+	d3 := orientation(p1, p2, q1)
+	d4 := orientation(p1, p2, q2)
+
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+
+	return (d1 == 0 && onSegment(q1, q2, p1)) ||
+		(d2 == 0 && onSegment(q1, q2, p2)) ||
+		(d3 == 0 && onSegment(p1, p2, q1)) ||
+		(d4 == 0 && onSegment(p1, p2, q2))
+}
+
+func orientation(a, b, c [2]float64) float64 {
+	return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+}
+
+// onSegment reports whether c, known to be collinear with a and b, lies between them.
+func onSegment(a, b, c [2]float64) bool {
+	return min(a[0], b[0]) <= c[0] && c[0] <= max(a[0], b[0]) &&
+		min(a[1], b[1]) <= c[1] && c[1] <= max(a[1], b[1])
+}
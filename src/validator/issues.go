@@ -0,0 +1,41 @@
+// Package validator
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-27
+package validator
+
+import "fmt"
+
+// IssueCode identifies the kind of problem found in GIS data.
+type IssueCode string
+
+const (
+	IssueMissingId          IssueCode = "missing_id"
+	IssueDuplicateId        IssueCode = "duplicate_id"
+	IssueInvalidCoordinates IssueCode = "invalid_coordinates"
+	IssueUnknownPoint       IssueCode = "unknown_point"
+	IssueDegenerate         IssueCode = "degenerate"
+	IssueUnclosedPolygon    IssueCode = "unclosed_polygon"
+	IssueSelfIntersection   IssueCode = "self_intersection"
+)
+
+// Issue is a single problem found in GIS data.
+// Path is a JSON path relative to the GIS data object, e.g. "$.lines[2].start".
+type Issue struct {
+	Code    IssueCode `json:"code"`
+	Path    string    `json:"path"`
+	Id      string    `json:"id,omitempty"`
+	Message string    `json:"message"`
+}
+
+// IssuesError is returned when GIS data fails validation. It wraps ErrInvalidGISData.
+type IssuesError struct {
+	Issues []Issue
+}
+
+func (e *IssuesError) Error() string {
+	return fmt.Sprintf("%s: %d issue(s), first: %s", ErrInvalidGISData, len(e.Issues), e.Issues[0].Message)
+}
+
+func (e *IssuesError) Unwrap() error {
+	return ErrInvalidGISData
+}
@@ -0,0 +1,143 @@
+// Package validator
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-27
+package validator
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisdata"
+)
+
+// Validate checks the referential integrity and geometry of GIS data and returns every issue found.
+// Lines and polylines must reference existing points, coordinates must have three finite components,
+// and polygons must form a closed, non-self-intersecting ring. Rings may omit the closing vertex.
+func Validate(data gisdata.GISData) []Issue {
+	v := &validation{issues: []Issue{}}
+
+	points := v.points(data.Points)
+	v.lines(data.Lines, points)
+	v.polylines(data.Polylines, points)
+	v.polygons(data.Polygons)
+
+	return v.issues
+}
+
+// Check validates GIS data and returns an *IssuesError when any issue is found.
+func Check(data gisdata.GISData) error {
+	if issues := Validate(data); len(issues) > 0 {
+		return &IssuesError{Issues: issues}
+	}
+
+	return nil
+}
+
+type validation struct {
+	issues []Issue
+}
+
+func (v *validation) add(code IssueCode, path, id, format string, args ...any) {
+	v.issues = append(v.issues, Issue{
+		Code:    code,
+		Path:    path,
+		Id:      id,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// id reports missing and duplicate ids of one element kind.
+func (v *validation) id(seen map[string]bool, kind, path, id string) {
+	if id == "" {
+		v.add(IssueMissingId, path+".id", "", "%s has no id", kind)
+		return
+	}
+	if seen[id] {
+		v.add(IssueDuplicateId, path+".id", id, "%s id %q is used more than once", kind, id)
+	}
+	seen[id] = true
+}
+
+func (v *validation) coordinates(path, id string, c []float64) {
+	if len(c) != 3 {
+		v.add(IssueInvalidCoordinates, path, id, "expected 3 coordinates, got %d", len(c))
+		return
+	}
+	for _, f := range c {
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			v.add(IssueInvalidCoordinates, path, id, "coordinates must be finite numbers")
+			return
+		}
+	}
+}
+
+func (v *validation) points(points []gisdata.GISPoint) map[string]bool {
+	seen := map[string]bool{}
+	for i, p := range points {
+		path := fmt.Sprintf("$.points[%d]", i)
+		v.id(seen, "point", path, p.Id)
+		v.coordinates(path+".coordinates", p.Id, p.Coordinates)
+	}
+
+	return seen
+}
+
+func (v *validation) reference(points map[string]bool, path, id, ref string) {
+	if !points[ref] {
+		v.add(IssueUnknownPoint, path, id, "point %q does not exist", ref)
+	}
+}
+
+func (v *validation) lines(lines []gisdata.GISLine, points map[string]bool) {
+	seen := map[string]bool{}
+	for i, l := range lines {
+		path := fmt.Sprintf("$.lines[%d]", i)
+		v.id(seen, "line", path, l.Id)
+		v.reference(points, path+".start", l.Id, l.Start)
+		v.reference(points, path+".end", l.Id, l.End)
+		if l.Start != "" && l.Start == l.End {
+			v.add(IssueDegenerate, path, l.Id, "line starts and ends at point %q", l.Start)
+		}
+	}
+}
+
+func (v *validation) polylines(polylines []gisdata.GISPolyline, points map[string]bool) {
+	seen := map[string]bool{}
+	for i, pl := range polylines {
+		path := fmt.Sprintf("$.polylines[%d]", i)
+		v.id(seen, "polyline", path, pl.Id)
+		if len(pl.Nodes) < 2 {
+			v.add(IssueDegenerate, path+".nodes", pl.Id, "polyline needs at least 2 nodes, got %d", len(pl.Nodes))
+		}
+		for j, node := range pl.Nodes {
+			v.reference(points, fmt.Sprintf("%s.nodes[%d]", path, j), pl.Id, node)
+		}
+	}
+}
+
+func (v *validation) polygons(polygons []gisdata.GISPolygon) {
+	seen := map[string]bool{}
+	for i, pg := range polygons {
+		path := fmt.Sprintf("$.polygons[%d]", i)
+		v.id(seen, "polygon", path, pg.Id)
+
+		valid := true
+		for j, vertex := range pg.Vertices {
+			before := len(v.issues)
+			v.coordinates(fmt.Sprintf("%s.vertices[%d]", path, j), pg.Id, vertex)
+			valid = valid && len(v.issues) == before
+		}
+		if !valid {
+			continue
+		}
+
+		r := ring(pg.Vertices)
+		if len(r) < 3 {
+			v.add(IssueUnclosedPolygon, path+".vertices", pg.Id, "polygon needs at least 3 distinct vertices to close, got %d", len(r))
+			continue
+		}
+		if a, b := selfIntersection(r); a >= 0 {
+			v.add(IssueSelfIntersection, path+".vertices", pg.Id, "polygon edges %d and %d intersect", a, b)
+		}
+	}
+}
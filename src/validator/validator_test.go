@@ -0,0 +1,72 @@
+// Package validator
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-27
+package validator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisdata"
+)
+
+func validData() gisdata.GISData {
+	return gisdata.GISData{
+		Points: []gisdata.GISPoint{
+			{Id: "n1", Coordinates: []float64{0, 0, 0}},
+			{Id: "n2", Coordinates: []float64{10, 0, 0}},
+			{Id: "n3", Coordinates: []float64{10, 10, 0}},
+		},
+		Lines: []gisdata.GISLine{
+			{Id: "l1", Start: "n1", End: "n2"},
+		},
+		Polylines: []gisdata.GISPolyline{
+			{Id: "pl1", Nodes: []string{"n1", "n2", "n3"}},
+		},
+		Polygons: []gisdata.GISPolygon{
+			{Id: "pg1", Vertices: [][]float64{{0, 0, 0}, {5, 0, 0}, {5, 5, 0}, {0, 5, 0}}},
+			{Id: "pg2", Vertices: [][]float64{{0, 0, 0}, {5, 0, 0}, {5, 5, 0}, {0, 0, 0}}},
+		},
+	}
+}
+
+func TestValidateValid(t *testing.T) {
+	if issues := Validate(validData()); len(issues) != 0 {
+		t.Errorf("Expected no issues, got %+v", issues)
+	}
+	if err := Check(validData()); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestValidateIssues(t *testing.T) {
+	data := validData()
+	data.Points = append(data.Points, gisdata.GISPoint{Id: "n1", Coordinates: []float64{1, 1}})
+	data.Lines[0].End = "n9"
+	data.Polylines[0].Nodes = []string{"n1"}
+	data.Polygons[0].Vertices = [][]float64{{0, 0, 0}, {5, 5, 0}, {5, 0, 0}, {0, 5, 0}}
+	data.Polygons[1].Vertices = [][]float64{{0, 0, 0}, {5, 0, 0}, {0, 0, 0}}
+
+	want := map[string]IssueCode{
+		"$.points[3].id":          IssueDuplicateId,
+		"$.points[3].coordinates": IssueInvalidCoordinates,
+		"$.lines[0].end":          IssueUnknownPoint,
+		"$.polylines[0].nodes":    IssueDegenerate,
+		"$.polygons[0].vertices":  IssueSelfIntersection,
+		"$.polygons[1].vertices":  IssueUnclosedPolygon,
+	}
+
+	issues := Validate(data)
+	if len(issues) != len(want) {
+		t.Fatalf("Expected %d issues, got %+v", len(want), issues)
+	}
+	for _, issue := range issues {
+		if want[issue.Path] != issue.Code {
+			t.Errorf("Unexpected issue %+v", issue)
+		}
+	}
+
+	if err := Check(data); !errors.Is(err, ErrInvalidGISData) {
+		t.Errorf("Expected ErrInvalidGISData, got %v", err)
+	}
+}
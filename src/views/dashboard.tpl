@@ -5,4 +5,10 @@
 {{ end }}
 {{ define "modules" }}
     <script type="module" src="/js/dashboard.js"></script>
-{{ end }}
\ No newline at end of file
+{{ end }}
+{{ define "content" }}
+    <main class="container py-4">
+        <h1 class="h4 mb-3">Networks</h1>
+        <div id="catalogue"></div>
+    </main>
+{{ end }}
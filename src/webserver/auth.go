@@ -11,6 +11,7 @@ import (
 
 func registerAuthEndpoints(app *fiber.App) fiber.Router {
 	auth := app.Group("/auth")
+	auth.Get("/refresh", endpoints.HandleRefreshRedirect)
 	auth.Post("/:action", endpoints.HandleAuthAction)
 
 	return auth
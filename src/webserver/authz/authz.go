@@ -0,0 +1,146 @@
+// Package authz
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-19
+package authz
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/config"
+	"github.com/teocci/go-hynix-3d-viewer/src/db"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/session"
+)
+
+// Action is an operation a user attempts on a resource.
+type Action int
+
+const (
+	ActionRead Action = iota
+	ActionWrite
+	ActionAdmin
+)
+
+// requiredRole maps each action to the lowest role allowed to perform it.
+var requiredRole = map[Action]db.Role{
+	ActionRead:  db.RoleViewer,
+	ActionWrite: db.RoleEditor,
+	ActionAdmin: db.RoleAdmin,
+}
+
+// RequireRole checks the global role of the current user.
+func RequireRole(c *fiber.Ctx, role db.Role) error {
+	user, ok := session.CurrentUser(c)
+	if !ok {
+		return ErrNotAuthenticated
+	}
+
+	if !user.Role.Allows(role) {
+		return ErrInsufficientRole
+	}
+
+	return nil
+}
+
+// Provider checks that the current user may perform the action on a provider.
+func Provider(c *fiber.Ctx, providerUUID string, action Action) error {
+	user, ok := session.CurrentUser(c)
+	if !ok {
+		return ErrNotAuthenticated
+	}
+
+	return UserProvider(user, providerUUID, action)
+}
+
+// Network checks that the current user may perform the action on a network.
+func Network(c *fiber.Ctx, networkUUID string, action Action) error {
+	return resource(c, db.ResourceNetwork, networkUUID, action)
+}
+
+// ProfileNetwork checks that the current user may perform the action on a network of a profile.
+// Provider resources do not record the profile of a network, so a grant only covers the
+// networks of the active profile; the other profiles are left to admins.
+func ProfileNetwork(c *fiber.Ctx, profile, networkUUID string, action Action) error {
+	user, ok := session.CurrentUser(c)
+	if !ok {
+		return ErrNotAuthenticated
+	}
+	if profile != config.Get().Profile && !user.IsAdmin() {
+		return ErrProfileNotGranted
+	}
+
+	return UserResource(user, db.ResourceNetwork, networkUUID, action)
+}
+
+// Collection checks that the current user may perform the action on a collection.
+func Collection(c *fiber.Ctx, collectionUUID string, action Action) error {
+	return resource(c, db.ResourceCollection, collectionUUID, action)
+}
+
+// UserProvider checks a provider grant for the given user.
+// Admins pass every check; other users need a grant whose role, capped by their global role,
+// allows the action.
+func UserProvider(user *db.User, providerUUID string, action Action) error {
+	required := requiredRole[action]
+	if user.IsAdmin() {
+		return nil
+	}
+
+	granted, err := db.GetUserProviderRole(user.UUID, providerUUID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return ErrNoProviderGrant
+		}
+		return err
+	}
+
+	if !user.Role.Min(granted).Allows(required) {
+		return ErrInsufficientRole
+	}
+
+	return nil
+}
+
+// UserResource checks that the user may perform the action through any provider owning the resource.
+func UserResource(user *db.User, kind db.ResourceKind, resourceUUID string, action Action) error {
+	if user.IsAdmin() {
+		return nil
+	}
+
+	owners, err := db.GetResourceProviderUUIDs(kind, resourceUUID)
+	if err != nil {
+		return err
+	}
+
+	denied := ErrNoResourceGrant
+	for _, providerUUID := range owners {
+		err := UserProvider(user, providerUUID, action)
+		if err == nil {
+			return nil
+		}
+		if errors.Is(err, ErrInsufficientRole) {
+			denied = err
+		}
+	}
+
+	return denied
+}
+
+// IsForbidden reports whether the error is an authorization denial rather than a failure.
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden) ||
+		errors.Is(err, ErrNoProviderGrant) ||
+		errors.Is(err, ErrNoResourceGrant) ||
+		errors.Is(err, ErrInsufficientRole) ||
+		errors.Is(err, ErrProfileNotGranted)
+}
+
+func resource(c *fiber.Ctx, kind db.ResourceKind, resourceUUID string, action Action) error {
+	user, ok := session.CurrentUser(c)
+	if !ok {
+		return ErrNotAuthenticated
+	}
+
+	return UserResource(user, kind, resourceUUID, action)
+}
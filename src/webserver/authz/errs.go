@@ -0,0 +1,15 @@
+// Package authz
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-19
+package authz
+
+import "errors"
+
+var (
+	ErrForbidden         = errors.New("forbidden")
+	ErrNotAuthenticated  = errors.New("not authenticated")
+	ErrNoProviderGrant   = errors.New("user has no access to this provider")
+	ErrNoResourceGrant   = errors.New("user has no access to this resource")
+	ErrInsufficientRole  = errors.New("insufficient role")
+	ErrProfileNotGranted = errors.New("only admins may access the networks of another profile")
+)
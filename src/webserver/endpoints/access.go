@@ -0,0 +1,25 @@
+// Package endpoints
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-19
+package endpoints
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/authz"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
+)
+
+// accessDenied renders an authorization error with the matching status code.
+func accessDenied(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, authz.ErrNotAuthenticated):
+		return renders.JSONUnauthorized(c, err)
+	case authz.IsForbidden(err):
+		return renders.JSONForbidden(c, err)
+	default:
+		return renders.JSONInternalError(c, err)
+	}
+}
@@ -0,0 +1,124 @@
+// Package endpoints
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-14
+package endpoints
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/authz"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/requests"
+)
+
+// maxAttributeRefs bounds the selection of a single attributes lookup.
+const maxAttributeRefs = 1000
+
+// NetworkNodeAttributes returns the attributes of a node, given by id or guid.
+func NetworkNodeAttributes(c *fiber.Ctx) error {
+	uuid, refs, err := featureParams(c)
+	if err != nil {
+		return renders.JSONBadRequest(c, err)
+	}
+
+	if err := authz.Network(c, uuid, authz.ActionRead); err != nil {
+		return accessDenied(c, err)
+	}
+
+	list, err := gisapi.Source().NodeAttributes(c.UserContext(), uuid, refs)
+	if err != nil {
+		return upstreamError(c, err)
+	}
+	if len(list) == 0 {
+		return renders.JSONNotFound(c, ErrNodeNotFound)
+	}
+
+	return renders.JSONOKResponse(c, renders.R{"uuid": uuid, "data": list[0]})
+}
+
+// NetworkLinkAttributes returns the attributes of a link, given by id or guid.
+func NetworkLinkAttributes(c *fiber.Ctx) error {
+	uuid, refs, err := featureParams(c)
+	if err != nil {
+		return renders.JSONBadRequest(c, err)
+	}
+
+	if err := authz.Network(c, uuid, authz.ActionRead); err != nil {
+		return accessDenied(c, err)
+	}
+
+	list, err := gisapi.Source().LinkAttributes(c.UserContext(), uuid, refs)
+	if err != nil {
+		return upstreamError(c, err)
+	}
+	if len(list) == 0 {
+		return renders.JSONNotFound(c, ErrLinkNotFound)
+	}
+
+	return renders.JSONOKResponse(c, renders.R{"uuid": uuid, "data": list[0]})
+}
+
+// NetworkAttributes looks up the attributes of a selection of nodes and links in one call.
+// Features that are not found are left out of the result.
+func NetworkAttributes(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	var req requests.NetworkAttributesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return renders.JSONBadRequest(c, ErrInvalidPayload)
+	}
+	if req.Nodes.Len()+req.Links.Len() == 0 {
+		return renders.JSONBadRequest(c, ErrSelectionRequired)
+	}
+	if req.Nodes.Len()+req.Links.Len() > maxAttributeRefs {
+		return renders.JSONBadRequest(c, ErrSelectionTooLarge)
+	}
+
+	if err := authz.Network(c, uuid, authz.ActionRead); err != nil {
+		return accessDenied(c, err)
+	}
+
+	nodes := []gisapi.NodeAttributes{}
+	if req.Nodes.Len() > 0 {
+		list, err := gisapi.Source().NodeAttributes(c.UserContext(), uuid, req.Nodes)
+		if err != nil {
+			return upstreamError(c, err)
+		}
+		nodes = list
+	}
+
+	links := []gisapi.LinkAttributes{}
+	if req.Links.Len() > 0 {
+		list, err := gisapi.Source().LinkAttributes(c.UserContext(), uuid, req.Links)
+		if err != nil {
+			return upstreamError(c, err)
+		}
+		links = list
+	}
+
+	return renders.JSONOKResponse(c, renders.R{"uuid": uuid, "data": renders.R{"nodes": nodes, "links": links}})
+}
+
+// featureParams reads the network uuid and the :id of a feature, which is a numeric id or a guid.
+func featureParams(c *fiber.Ctx) (string, gisapi.FeatureRefs, error) {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return "", gisapi.FeatureRefs{}, ErrUUIDRequired
+	}
+
+	ref := c.Params("id")
+	if ref == "" {
+		return "", gisapi.FeatureRefs{}, ErrFeatureRequired
+	}
+	if id, err := strconv.Atoi(ref); err == nil {
+		return uuid, gisapi.FeatureRefs{IDs: []int{id}}, nil
+	}
+
+	return uuid, gisapi.FeatureRefs{Guids: []string{ref}}, nil
+}
@@ -4,42 +4,46 @@
 package endpoints
 
 import (
+	"errors"
 	"log"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/golang-jwt/jwt/v5"
-
-	"golang.org/x/crypto/bcrypt"
 
 	"github.com/teocci/go-hynix-3d-viewer/src/db"
 	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/session"
 )
 
-// JWT Secret Key (should be stored in an environment variable or config file)
-var jwtSecret = []byte("super-secret-key")
-
 // LoginRequest represents the expected login request body
 type LoginRequest struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
 }
 
-// HandleAuthAction processes login and logout actions.
+const (
+	AuthActionLogin   = "login"
+	AuthActionLogout  = "logout"
+	AuthActionRefresh = "refresh"
+)
+
+// HandleAuthAction processes login, logout and refresh actions.
 func HandleAuthAction(c *fiber.Ctx) error {
 	action := c.Params("action")
 
 	switch action {
-	case "login":
+	case AuthActionLogin:
 		return handleLogin(c)
-	case "logout":
+	case AuthActionLogout:
 		return handleLogout(c)
+	case AuthActionRefresh:
+		return handleRefresh(c)
 	default:
 		return renders.JSONBadRequest(c, ErrInvalidAuthAction)
 	}
 }
 
-// handleLogin authenticates the user and stores JWT in an HTTP-only cookie.
+// handleLogin authenticates the user and stores the access and refresh tokens in HTTP-only cookies.
 func handleLogin(c *fiber.Ctx) error {
 	var req LoginRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -52,67 +56,148 @@ func handleLogin(c *fiber.Ctx) error {
 	}
 
 	// Retrieve user from the database
-	dbInstance := db.GetDB()
-	var user db.User
-	err := dbInstance.Where("username = ?", req.Username).First(&user).Error
+	user, err := db.GetUserByUsername(req.Username)
 	if err != nil {
 		return renders.JSONUnauthorized(c, ErrInvalidCredentials)
 	}
 
 	// Validate password
-	if !checkPassword(req.Password, user.PasswordHash) {
+	if !user.CheckPassword(req.Password) {
 		return renders.JSONUnauthorized(c, ErrInvalidCredentials)
 	}
 
-	// Generate JWT token with a 1-year expiration
-	token, err := generateJWT(user.UUID)
-	if err != nil {
-		log.Println("Error generating JWT:", err)
-		return renders.JSONInternalError(c, ErrTokenGeneration)
+	if user.Disabled {
+		return renders.JSONForbidden(c, session.ErrUserDisabled)
 	}
 
-	// Store JWT in an HTTP-only secure cookie
-	c.Cookie(&fiber.Cookie{
-		Name:     "token",
-		Value:    token,
-		Expires:  time.Now().Add(365 * 24 * time.Hour), // 1 year expiration
-		HTTPOnly: true,                                 // Prevents JavaScript access (XSS protection)
-		Secure:   true,                                 // Requires HTTPS (enable in production)
-		SameSite: "Strict",
-	})
+	if err := issueTokens(c, user.UUID); err != nil {
+		log.Println("Error generating tokens:", err)
+		return renders.JSONInternalError(c, ErrTokenGeneration)
+	}
 
 	return c.JSON(fiber.Map{"message": "Login successful"})
 }
 
-// handleLogout clears the JWT cookie.
+// handleRefresh exchanges a valid refresh token for a new access and refresh token pair.
+func handleRefresh(c *fiber.Ctx) error {
+	if err := refreshTokens(c); err != nil {
+		switch {
+		case errors.Is(err, ErrTokenGeneration):
+			return renders.JSONInternalError(c, err)
+		case errors.Is(err, session.ErrUserDisabled):
+			return renders.JSONForbidden(c, err)
+		default:
+			return renders.JSONUnauthorized(c, err)
+		}
+	}
+
+	return c.JSON(fiber.Map{"message": "Token refreshed"})
+}
+
+// HandleRefreshRedirect renews the session of a page request whose access token expired and
+// sends the browser back to ?next, or to the login page when the session cannot be renewed.
+func HandleRefreshRedirect(c *fiber.Ctx) error {
+	if err := refreshTokens(c); err != nil {
+		return c.Redirect(session.LoginPagePath, fiber.StatusSeeOther)
+	}
+
+	return c.Redirect(session.LocalRedirect(c.Query("next")), fiber.StatusSeeOther)
+}
+
+// refreshTokens rotates the refresh token of the request and issues a new token pair.
+// The cookies are cleared when the session is over.
+func refreshTokens(c *fiber.Ctx) error {
+	userUUID, err := session.RotateRefreshToken(session.ExtractRefreshToken(c))
+	if err != nil {
+		clearTokens(c)
+		return err
+	}
+
+	user, err := db.GetUserByUUID(userUUID)
+	if err != nil {
+		clearTokens(c)
+		return session.ErrUserNotFound
+	}
+	if user.Disabled {
+		clearTokens(c)
+		return session.ErrUserDisabled
+	}
+
+	if err := issueTokens(c, userUUID); err != nil {
+		log.Println("Error generating tokens:", err)
+		return ErrTokenGeneration
+	}
+
+	return nil
+}
+
+// handleLogout revokes the refresh token and clears the JWT cookies.
 func handleLogout(c *fiber.Ctx) error {
-	c.Cookie(&fiber.Cookie{
-		Name:     "token",
-		Value:    "",
-		Expires:  time.Now().Add(-1 * time.Hour), // Expire immediately
-		HTTPOnly: true,
-		Secure:   true,
-		SameSite: "Strict",
-	})
+	if err := session.RevokeRefreshToken(session.ExtractRefreshToken(c)); err != nil {
+		log.Println("Error revoking refresh token:", err)
+	}
+
+	clearTokens(c)
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Logged out successfully",
 	})
 }
 
-// checkPassword compares the hashed password from the database with the provided password.
-func checkPassword(password, hashedPassword string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
-	return err == nil
-}
+// issueTokens generates an access and refresh token pair and stores them in HTTP-only cookies.
+func issueTokens(c *fiber.Ctx, userUUID string) error {
+	access, accessExpires, err := session.GenerateToken(userUUID)
+	if err != nil {
+		return err
+	}
 
-// generateJWT creates a JWT token with user UUID and 1-year expiration.
-func generateJWT(userUUID string) (string, error) {
-	claims := jwt.MapClaims{
-		"uuid": userUUID,
-		"exp":  time.Now().Add(365 * 24 * time.Hour).Unix(), // Token expires in 1 year
+	refresh, refreshExpires, err := session.GenerateRefreshToken(userUUID)
+	if err != nil {
+		return err
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(jwtSecret)
+	c.Cookie(&fiber.Cookie{
+		Name:     session.CookieName,
+		Value:    access,
+		Expires:  accessExpires,
+		HTTPOnly: true, // Prevents JavaScript access (XSS protection)
+		Secure:   true, // Requires HTTPS (enable in production)
+		SameSite: "Strict",
+	})
+
+	// The refresh token is only sent back to the auth endpoints.
+	c.Cookie(&fiber.Cookie{
+		Name:     session.RefreshCookieName,
+		Value:    refresh,
+		Path:     session.RefreshCookiePath,
+		Expires:  refreshExpires,
+		HTTPOnly: true,
+		Secure:   true,
+		SameSite: "Strict",
+	})
+
+	return nil
+}
+
+// clearTokens expires both JWT cookies.
+func clearTokens(c *fiber.Ctx) {
+	expired := time.Now().Add(-1 * time.Hour) // Expire immediately
+
+	c.Cookie(&fiber.Cookie{
+		Name:     session.CookieName,
+		Value:    "",
+		Expires:  expired,
+		HTTPOnly: true,
+		Secure:   true,
+		SameSite: "Strict",
+	})
+	c.Cookie(&fiber.Cookie{
+		Name:     session.RefreshCookieName,
+		Value:    "",
+		Path:     session.RefreshCookiePath,
+		Expires:  expired,
+		HTTPOnly: true,
+		Secure:   true,
+		SameSite: "Strict",
+	})
 }
@@ -0,0 +1,135 @@
+// Package endpoints
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-10
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/config"
+	"github.com/teocci/go-hynix-3d-viewer/src/db"
+	"github.com/teocci/go-hynix-3d-viewer/src/geocache"
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/authz"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
+)
+
+// cachedNodes returns the nodes of a network from the geometry cache of the active profile.
+func cachedNodes(ctx context.Context, uuid string) (gisapi.NodesData, geocache.Meta, error) {
+	return profileNodes(ctx, config.Get().Profile, uuid)
+}
+
+// cachedLinks returns the links of a network from the geometry cache of the active profile.
+func cachedLinks(ctx context.Context, uuid string) (gisapi.LinksData, geocache.Meta, error) {
+	return profileLinks(ctx, config.Get().Profile, uuid)
+}
+
+// profileNodes returns the nodes of a network of any configured profile from the geometry cache.
+func profileNodes(ctx context.Context, profile, uuid string) (gisapi.NodesData, geocache.Meta, error) {
+	key := geocache.Key{Profile: profile, Network: uuid, Kind: NetworkKindNodes}
+	return geocache.Get(ctx, geocache.Default(), key, func(ctx context.Context) (gisapi.NodesData, error) {
+		source, err := gisapi.ProfileSource(profile)
+		if err != nil {
+			return nil, err
+		}
+		return gisapi.Collect(source.Nodes(ctx, uuid))
+	})
+}
+
+// profileLinks returns the links of a network of any configured profile like profileNodes.
+func profileLinks(ctx context.Context, profile, uuid string) (gisapi.LinksData, geocache.Meta, error) {
+	key := geocache.Key{Profile: profile, Network: uuid, Kind: NetworkKindLinks}
+	return geocache.Get(ctx, geocache.Default(), key, func(ctx context.Context) (gisapi.LinksData, error) {
+		source, err := gisapi.ProfileSource(profile)
+		if err != nil {
+			return nil, err
+		}
+		return gisapi.Collect(source.Links(ctx, uuid))
+	})
+}
+
+func cacheKey(uuid, kind string) geocache.Key {
+	return geocache.Key{Profile: config.Get().Profile, Network: uuid, Kind: kind}
+}
+
+// networkKey keys the values built from a network of a profile, such as its spatial index.
+func networkKey(profile, uuid string) string {
+	return profile + "/" + uuid
+}
+
+// notModified sets the ETag and Last-Modified headers of a response built from cached
+// entries and reports whether the client copy is still fresh. The ETag covers the query
+// and the Accept header too, since they change the encoding of the same data.
+func notModified(c *fiber.Ctx, metas ...geocache.Meta) bool {
+	h := fnv.New64a()
+	var modified time.Time
+	for _, m := range metas {
+		_, _ = h.Write([]byte(m.ETag))
+		if m.Modified.After(modified) {
+			modified = m.Modified
+		}
+	}
+	_, _ = h.Write(c.Request().URI().QueryString())
+	_, _ = h.Write([]byte(c.Get(fiber.HeaderAccept)))
+
+	c.Set(fiber.HeaderETag, fmt.Sprintf(`"%016x"`, h.Sum64()))
+	c.Set(fiber.HeaderLastModified, modified.UTC().Format(time.RFC1123))
+
+	return c.Fresh()
+}
+
+// CacheStats reports the memory use of the geometry cache.
+func CacheStats(c *fiber.Ctx) error {
+	if err := authz.RequireRole(c, db.RoleAdmin); err != nil {
+		return accessDenied(c, err)
+	}
+
+	return renders.JSONDataResponse(c, geocache.Default().Stats())
+}
+
+// CacheInvalidate drops every cached network, or the networks of ?profile when given.
+func CacheInvalidate(c *fiber.Ctx) error {
+	if err := authz.RequireRole(c, db.RoleAdmin); err != nil {
+		return accessDenied(c, err)
+	}
+
+	removed, err := geocache.Default().Invalidate(c.Query("profile"), "")
+	if err != nil {
+		return renders.JSONInternalError(c, err)
+	}
+	spatialIndexes.Clear()
+	tilesets.Clear()
+	catalogues.Clear()
+	typeRegistries.Clear()
+
+	return renders.JSONDataSuccessResponse(c, renders.R{"removed": removed})
+}
+
+// CacheInvalidateNetwork drops the cached geometry of a network of the active profile,
+// or of ?profile when given, along with the indexes built from it.
+func CacheInvalidateNetwork(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	if err := authz.RequireRole(c, db.RoleAdmin); err != nil {
+		return accessDenied(c, err)
+	}
+
+	profile := c.Query("profile", config.Get().Profile)
+	removed, err := geocache.Default().Invalidate(profile, uuid)
+	if err != nil {
+		return renders.JSONInternalError(c, err)
+	}
+	spatialIndexes.Invalidate(networkKey(profile, uuid))
+	tilesets.Invalidate(networkKey(profile, uuid))
+	catalogues.Invalidate(profile)
+
+	return renders.JSONDataSuccessResponse(c, renders.R{"uuid": uuid, "removed": removed})
+}
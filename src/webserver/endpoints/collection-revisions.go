@@ -0,0 +1,170 @@
+// Package endpoints
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-26
+package endpoints
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/db"
+	"github.com/teocci/go-hynix-3d-viewer/src/gisdata"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/authz"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/session"
+)
+
+// RevisionInfo describes a collection revision. GIS is only set when a single revision is requested.
+type RevisionInfo struct {
+	Number    int              `json:"number"`
+	Name      string           `json:"name"`
+	Author    string           `json:"author,omitempty"`
+	Message   string           `json:"message,omitempty"`
+	CreatedAt time.Time        `json:"createdAt"`
+	GIS       *gisdata.GISData `json:"gis,omitempty"`
+}
+
+// RevisionDiff is the difference between two revisions of a collection.
+type RevisionDiff struct {
+	From     int             `json:"from"`
+	To       int             `json:"to"`
+	FromName string          `json:"fromName"`
+	ToName   string          `json:"toName"`
+	Changed  bool            `json:"changed"`
+	Diff     gisdata.GISDiff `json:"diff"`
+}
+
+func asRevisionInfo(r db.GISCollectionRevision, withData bool) RevisionInfo {
+	info := RevisionInfo{
+		Number:    r.Number,
+		Name:      r.Name,
+		Author:    r.AuthorUUID,
+		Message:   r.Message,
+		CreatedAt: r.CreatedAt,
+	}
+	if withData {
+		data := r.Data
+		info.GIS = &data
+	}
+
+	return info
+}
+
+func CollectionRevisions(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	if err := authz.Collection(c, uuid, authz.ActionRead); err != nil {
+		return accessDenied(c, err)
+	}
+
+	revisions, err := db.ListGISCollectionRevisions(uuid)
+	if err != nil {
+		return renders.JSONInternalError(c, err)
+	}
+	if len(revisions) == 0 {
+		return renders.JSONNotFound(c, db.ErrNotFound)
+	}
+
+	list := make([]RevisionInfo, len(revisions))
+	for i, r := range revisions {
+		list[i] = asRevisionInfo(r, false)
+	}
+
+	return renders.JSONDataResponse(c, list)
+}
+
+func CollectionRevision(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	number, err := c.ParamsInt("revision")
+	if err != nil || number < 1 {
+		return renders.JSONBadRequest(c, ErrInvalidRevision)
+	}
+
+	if err := authz.Collection(c, uuid, authz.ActionRead); err != nil {
+		return accessDenied(c, err)
+	}
+
+	revision, err := db.GetGISCollectionRevision(uuid, number)
+	if err != nil {
+		return collectionError(c, err)
+	}
+
+	return renders.JSONDataResponse(c, asRevisionInfo(revision, true))
+}
+
+// CollectionDiff compares two revisions given by the from and to queries.
+// When to is omitted the latest revision is used.
+func CollectionDiff(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	from := c.QueryInt("from")
+	to := c.QueryInt("to")
+	if from < 1 || to < 0 {
+		return renders.JSONBadRequest(c, ErrInvalidRevision)
+	}
+
+	if err := authz.Collection(c, uuid, authz.ActionRead); err != nil {
+		return accessDenied(c, err)
+	}
+
+	older, err := db.GetGISCollectionRevision(uuid, from)
+	if err != nil {
+		return collectionError(c, err)
+	}
+
+	var newer db.GISCollectionRevision
+	if to == 0 {
+		newer, err = db.GetLatestGISCollectionRevision(uuid)
+	} else {
+		newer, err = db.GetGISCollectionRevision(uuid, to)
+	}
+	if err != nil {
+		return collectionError(c, err)
+	}
+
+	diff := gisdata.Diff(older.Data, newer.Data)
+
+	return renders.JSONDataResponse(c, RevisionDiff{
+		From:     older.Number,
+		To:       newer.Number,
+		FromName: older.Name,
+		ToName:   newer.Name,
+		Changed:  !diff.Empty() || older.Name != newer.Name,
+		Diff:     diff,
+	})
+}
+
+// CollectionRollback restores an old revision by recording it as a new one.
+func CollectionRollback(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	number, err := c.ParamsInt("revision")
+	if err != nil || number < 1 {
+		return renders.JSONBadRequest(c, ErrInvalidRevision)
+	}
+
+	if err := authz.Collection(c, uuid, authz.ActionWrite); err != nil {
+		return accessDenied(c, err)
+	}
+
+	collection, err := db.RollbackGISCollection(uuid, number, session.CurrentUserUUID(c))
+	if err != nil {
+		return collectionError(c, err)
+	}
+
+	return renders.JSONOKResponse(c, collection.AsGISCollection(true))
+}
@@ -3,29 +3,194 @@
 // Author: teocci@yandex.com on 2025-2월-10
 package endpoints
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/db"
+	"github.com/teocci/go-hynix-3d-viewer/src/geojson"
+	"github.com/teocci/go-hynix-3d-viewer/src/gisdata"
+	"github.com/teocci/go-hynix-3d-viewer/src/validator"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/authz"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/parsers"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/requests"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/session"
+)
 
 func Collection(c *fiber.Ctx) error {
-	gis := GISData{
-		Points: []GISPoint{
-			{Id: "n1", Coordinates: []int{0, 0, 0}},
-			{Id: "n2", Coordinates: []int{10, 0, 0}},
-			{Id: "n3", Coordinates: []int{10, 10, 0}},
-			{Id: "n4", Coordinates: []int{0, 10, 0}},
-			{Id: "n5", Coordinates: []int{5, 5, 10}},
-		},
-		Lines: []GISLine{
-			{Id: "l1", Start: "n1", End: "n2"},
-			{Id: "l2", Start: "n2", End: "n3"},
-		},
-		Polylines: []GISPolyline{
-			{Id: "pl1", Nodes: []string{"n1", "n2", "n3", "n4"}},
-		},
-		Polygons: []GISPolygon{
-			{Id: "pg1", Vertices: [][]int{{15, 0, 0}, {25, 0, 0}, {25, 10, 0}, {15, 10, 0}}},
-		},
-	}
-	data := fiber.Map{"gis": gis}
-
-	return c.JSON(data)
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	if err := authz.Collection(c, uuid, authz.ActionRead); err != nil {
+		return accessDenied(c, err)
+	}
+
+	collection, err := db.GetGISCollection(uuid, true)
+	if err != nil {
+		return collectionError(c, err)
+	}
+
+	if parsers.WantsGeoJSON(c) {
+		return renders.StreamResponseWithType(c, geojson.MIMEType, geojson.FromGISCollections(GISCollections{collection.AsGISCollection(true)}))
+	}
+
+	return renders.JSONOKResponse(c, collection.AsGISCollection(true))
+}
+
+func CollectionCreate(c *fiber.Ctx) error {
+	var req requests.CollectionCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return renders.JSONBadRequest(c, ErrInvalidPayload)
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return renders.JSONBadRequest(c, ErrNameRequired)
+	}
+	if req.Provider == "" {
+		return renders.JSONBadRequest(c, ErrProviderRequired)
+	}
+
+	if err := authz.Provider(c, req.Provider, authz.ActionWrite); err != nil {
+		return accessDenied(c, err)
+	}
+
+	var data gisdata.GISData
+	if req.GIS != nil {
+		data = *req.GIS
+	}
+
+	collection, err := db.CreateGISCollection("", req.Name, req.Provider, session.CurrentUserUUID(c), data)
+	if err != nil {
+		return collectionError(c, err)
+	}
+
+	return renders.JSONResponse(c, fiber.StatusCreated, collection.AsGISCollection(true))
+}
+
+// CollectionImportGeoJSON creates a collection from a GeoJSON FeatureCollection body.
+// The provider query is required; the name query defaults to the FeatureCollection name.
+func CollectionImportGeoJSON(c *fiber.Ctx) error {
+	provider, err := parsers.QueryProvider(c)
+	if err != nil {
+		return renders.JSONBadRequest(c, ErrProviderRequired)
+	}
+
+	if err := authz.Provider(c, provider, authz.ActionWrite); err != nil {
+		return accessDenied(c, err)
+	}
+
+	var fc geojson.FeatureCollection
+	if err := json.Unmarshal(c.Body(), &fc); err != nil {
+		return renders.JSONBadRequest(c, fmt.Errorf("%w: %v", ErrInvalidGeoJSON, err))
+	}
+
+	data, err := geojson.ToGISData(fc)
+	if err != nil {
+		return renders.JSONBadRequest(c, fmt.Errorf("%w: %v", ErrInvalidGeoJSON, err))
+	}
+
+	name := strings.TrimSpace(c.Query("name", fc.Name))
+	if name == "" {
+		return renders.JSONBadRequest(c, ErrNameRequired)
+	}
+
+	collection, err := db.CreateGISCollection("", name, provider, session.CurrentUserUUID(c), data)
+	if err != nil {
+		return collectionError(c, err)
+	}
+
+	return renders.JSONResponse(c, fiber.StatusCreated, collection.AsGISCollection(true))
+}
+
+func CollectionUpdate(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	if err := authz.Collection(c, uuid, authz.ActionWrite); err != nil {
+		return accessDenied(c, err)
+	}
+
+	var req requests.CollectionUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return renders.JSONBadRequest(c, ErrInvalidPayload)
+	}
+	if req.Name == nil && req.GIS == nil {
+		return renders.JSONBadRequest(c, ErrNothingToUpdate)
+	}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return renders.JSONBadRequest(c, ErrNameRequired)
+		}
+		req.Name = &name
+	}
+
+	collection, err := db.UpdateGISCollection(uuid, db.GISCollectionUpdate{
+		Name:       req.Name,
+		GIS:        req.GIS,
+		AuthorUUID: session.CurrentUserUUID(c),
+		Message:    req.Message,
+	})
+	if err != nil {
+		return collectionError(c, err)
+	}
+
+	return renders.JSONOKResponse(c, collection.AsGISCollection(true))
+}
+
+// CollectionValidate checks GIS data without storing it. Issue paths are relative to the posted object.
+func CollectionValidate(c *fiber.Ctx) error {
+	var data gisdata.GISData
+	if err := c.BodyParser(&data); err != nil {
+		return renders.JSONBadRequest(c, ErrInvalidPayload)
+	}
+
+	issues := validator.Validate(data)
+
+	return renders.JSONDataResponse(c, fiber.Map{
+		"valid":  len(issues) == 0,
+		"issues": issues,
+	})
+}
+
+func CollectionDelete(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	if err := authz.Collection(c, uuid, authz.ActionWrite); err != nil {
+		return accessDenied(c, err)
+	}
+
+	if err := db.DeleteGISCollection(uuid); err != nil {
+		return collectionError(c, err)
+	}
+
+	return renders.JSONSuccessResponse(c)
+}
+
+// collectionError maps collection service errors to response codes.
+func collectionError(c *fiber.Ctx, err error) error {
+	var invalid *validator.IssuesError
+	switch {
+	case errors.As(err, &invalid):
+		return renders.JSONUnprocessableWithIssues(c, validator.ErrInvalidGISData, invalid.Issues)
+	case errors.Is(err, db.ErrNotFound):
+		return renders.JSONNotFound(c, err)
+	case errors.Is(err, db.ErrExists):
+		return renders.JSONConflict(c, err)
+	default:
+		return renders.JSONInternalError(c, err)
+	}
 }
@@ -4,41 +4,60 @@
 package endpoints
 
 import (
-	"encoding/json"
-	"os"
-
 	"github.com/gofiber/fiber/v2"
-	"github.com/teocci/go-hynix-3d-viewer/src/webserver/parsers"
 
+	"github.com/teocci/go-hynix-3d-viewer/src/db"
+	"github.com/teocci/go-hynix-3d-viewer/src/geojson"
+	"github.com/teocci/go-hynix-3d-viewer/src/gisdata"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/authz"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/parsers"
 	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/session"
 )
 
-const dummyCollectionsDataPath = "./web/json/updated-collections.json"
+type GISCollection = gisdata.GISCollection
 
-type GISCollection struct {
-	Name string   `json:"name"`
-	UUID string   `json:"uuid"`
-	GIS  *GISData `json:"gis,omitempty"`
-}
-
-type GISCollections []GISCollection
+type GISCollections = gisdata.GISCollections
 
+// CollectionList lists the collections the user can read, without their GIS data.
+// An optional provider query narrows the list to a single provider.
 func CollectionList(c *fiber.Ctx) error {
-	// Load dummy from the JSON file
-	dummy, err := loadCollectionsFromFile(dummyCollectionsDataPath)
+	user, ok := session.CurrentUser(c)
+	if !ok {
+		return renders.JSONUnauthorized(c, authz.ErrNotAuthenticated)
+	}
+
+	var providerUUIDs []string
+	if provider, err := parsers.QueryProvider(c); err == nil {
+		if err := authz.Provider(c, provider, authz.ActionRead); err != nil {
+			return accessDenied(c, err)
+		}
+		providerUUIDs = []string{provider}
+	} else if !user.IsAdmin() {
+		providers, err := db.GetProvidersByUserUUID(user.UUID)
+		if err != nil {
+			return renders.JSONInternalError(c, ErrFailedToLoadCollections)
+		}
+		providerUUIDs = make([]string, len(providers))
+		for i, p := range providers {
+			providerUUIDs[i] = p.UUID
+		}
+	}
+
+	list, err := db.ListGISCollections(providerUUIDs)
 	if err != nil {
-		return renders.JSONBadRequest(c, ErrFailedToLoadCollections)
+		return renders.JSONInternalError(c, ErrFailedToLoadCollections)
 	}
 
-	var collections GISCollections
-	for _, raw := range dummy {
-		item := GISCollection{raw.Name, raw.UUID, nil}
-		collections = append(collections, item)
+	collections := GISCollections{}
+	for _, item := range list {
+		collections = append(collections, item.AsGISCollection(false))
 	}
 
 	return renders.JSONOKResponse(c, collections)
 }
 
+// Collections returns the requested collections with their GIS data, as GeoJSON when asked for.
 func Collections(c *fiber.Ctx) error {
 	uuids, err := parsers.QueryCollectionUUIDs(c)
 	if err != nil {
@@ -50,44 +69,25 @@ func Collections(c *fiber.Ctx) error {
 		return renders.JSONBadRequest(c, ErrAtLeastOneUUIDRequired)
 	}
 
-	// Load dummy from the JSON file
-	dummy, err := loadCollectionsFromFile(dummyCollectionsDataPath)
-	if err != nil {
-		return renders.JSONBadRequest(c, ErrFailedToLoadCollections)
-	}
-
-	// Filter dummy based on requested UUIDs
-	var collections GISCollections
-	for _, coll := range dummy {
-		if contains(uuids, coll.UUID) {
-			collections = append(collections, coll)
+	for _, uuid := range uuids {
+		if err := authz.Collection(c, uuid, authz.ActionRead); err != nil {
+			return accessDenied(c, err)
 		}
 	}
 
-	return renders.JSONOKResponse(c, collections)
-}
-
-func loadCollectionsFromFile(filePath string) (GISCollections, error) {
-	// Read the file
-	data, err := os.ReadFile(filePath)
+	list, err := db.GetGISCollectionsByUUIDs(uuids)
 	if err != nil {
-		return nil, err
+		return renders.JSONInternalError(c, ErrFailedToLoadCollections)
 	}
 
-	// Parse JSON data
-	var collections GISCollections
-	if err := json.Unmarshal(data, &collections); err != nil {
-		return nil, err
+	collections := GISCollections{}
+	for _, item := range list {
+		collections = append(collections, item.AsGISCollection(true))
 	}
 
-	return collections, nil
-}
-
-func contains(list []string, item string) bool {
-	for _, v := range list {
-		if v == item {
-			return true
-		}
+	if parsers.WantsGeoJSON(c) {
+		return renders.StreamResponseWithType(c, geojson.MIMEType, geojson.FromGISCollections(collections))
 	}
-	return false
+
+	return renders.JSONOKResponse(c, collections)
 }
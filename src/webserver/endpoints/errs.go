@@ -3,7 +3,11 @@
 // Author: teocci@yandex.com on 2025-2월-11
 package endpoints
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/session"
+)
 
 var (
 	ErrNotFound                = errors.New("not found")
@@ -13,15 +17,45 @@ var (
 	ErrMissingCredentials      = errors.New("username and password are required")
 	ErrInvalidCredentials      = errors.New("invalid username or password")
 	ErrTokenGeneration         = errors.New("could not generate token")
-	ErrInvalidToken            = errors.New("invalid token")
-	ErrTokenExpired            = errors.New("token expired")
-	ErrTokenMissing            = errors.New("token missing")
+	ErrInvalidToken            = session.ErrInvalidToken
+	ErrTokenExpired            = session.ErrTokenExpired
+	ErrTokenMissing            = session.ErrTokenMissing
 	ErrInvalidJSONFormat       = errors.New("invalid JSON format")
 	ErrUUIDRequired            = errors.New("UUID is required")
 	ErrAtLeastOneUUIDRequired  = errors.New("at least one UUID is required")
 	ErrFailedToParseUUID       = errors.New("failed to parse UUID")
 	ErrFailedToLoadPayload     = errors.New("failed to load collections data")
 	ErrFailedToLoadCollections = errors.New("failed to load collections")
+	ErrFailedToLoadNetworks    = errors.New("failed to load networks")
 	ErrKindRequired            = errors.New("kind is required")
 	ErrKindNotSupported        = errors.New("kind is not supported")
+	ErrNameRequired            = errors.New("name is required")
+	ErrPasswordTooShort        = errors.New("password must be at least 8 characters")
+	ErrCurrentPasswordInvalid  = errors.New("current password is invalid")
+	ErrNothingToUpdate         = errors.New("nothing to update")
+	ErrHostRequired            = errors.New("host is required")
+	ErrInvalidPort             = errors.New("port must be between 1 and 65535")
+	ErrRoleAboveGrant          = errors.New("cannot grant a role above your own")
+	ErrProviderRequired        = errors.New("provider UUID is required")
+	ErrInvalidRevision         = errors.New("revision must be a positive number")
+	ErrInvalidGeoJSON          = errors.New("invalid GeoJSON")
+	ErrInvalidNodeStyle        = errors.New("nodes must be points or mesh")
+	ErrAnalysisNotSupported    = errors.New("analysis is not supported")
+	ErrInvalidNodeId           = errors.New("node id must be a non-negative number")
+	ErrInvalidDirection        = errors.New("direction must be downstream, upstream or both")
+	ErrQueryModeNotSupported   = errors.New("mode must be bbox, radius or nearest")
+	ErrInvalidBBox             = errors.New("bbox must be minX,minY,minZ,maxX,maxY,maxZ")
+	ErrInvalidPoint            = errors.New("point must be x,y,z")
+	ErrInvalidRadius           = errors.New("radius must be a positive number")
+	ErrInvalidNearestCount     = errors.New("k must be between 1 and 1000")
+	ErrInvalidTileAddress      = errors.New("tile level, x, y and z must be non-negative numbers")
+	ErrSourceCRSUnknown        = errors.New("the profile does not declare the CRS of its geometry")
+	ErrInvalidOrigin           = errors.New("origin must be auto or x,y,z")
+	ErrFeatureRequired         = errors.New("node or link id or guid is required")
+	ErrNodeNotFound            = errors.New("node not found")
+	ErrLinkNotFound            = errors.New("link not found")
+	ErrSelectionRequired       = errors.New("at least one node or link is required")
+	ErrSelectionTooLarge       = errors.New("at most 1000 nodes and links can be looked up at once")
+	ErrNetworksRequired        = errors.New("at least one network is required")
+	ErrTooManyNetworks         = errors.New("at most 16 networks can be merged into a scene")
 )
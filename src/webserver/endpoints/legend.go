@@ -0,0 +1,141 @@
+// Package endpoints
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-15
+package endpoints
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/config"
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+	"github.com/teocci/go-hynix-3d-viewer/src/legend"
+	"github.com/teocci/go-hynix-3d-viewer/src/memo"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/authz"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/parsers"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
+)
+
+const typeRegistryTTL = 10 * time.Minute
+
+var typeRegistries = memo.New[*legend.Registry](typeRegistryTTL)
+
+// NetworkLegend lists the node and link types present in a network with their description
+// and count. ?all=true adds the described types the network does not use.
+func NetworkLegend(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	if err := authz.Network(c, uuid, authz.ActionRead); err != nil {
+		return accessDenied(c, err)
+	}
+
+	registry := typeRegistry(c.UserContext())
+
+	nodes, links, err := fetchNetwork(c.UserContext(), uuid)
+	if err != nil {
+		return upstreamError(c, err)
+	}
+
+	data := registry.Legend(nodes, links)
+	if c.QueryBool("all") {
+		data.Nodes = withUnused(data.Nodes, registry.Types(legend.KindNodes))
+		data.Links = withUnused(data.Links, registry.Types(legend.KindLinks))
+	}
+
+	return renders.JSONOKResponse(c, renders.R{"uuid": uuid, "data": data})
+}
+
+// typeRegistry returns the types of the active profile: the ones of the GIS API, when its
+// source describes them, overridden by the ones of the config. When the GIS API cannot be
+// reached, the config alone gives the legend; that fallback is not cached, so the backend
+// types are picked up again as soon as it answers.
+func typeRegistry(ctx context.Context) *legend.Registry {
+	profile := config.ActiveProfile()
+	registry, err := typeRegistries.Get(config.Get().Profile, func() (*legend.Registry, error) {
+		registry := legend.NewRegistry()
+
+		if source, ok := gisapi.Source().(gisapi.TypeSource); ok {
+			types, err := source.Types(context.WithoutCancel(ctx))
+			if err != nil {
+				return nil, err
+			}
+			registry.AddAPI(types)
+		}
+		registry.AddConfig(profile.Types)
+
+		return registry, nil
+	})
+	if err != nil {
+		log.Printf("Cannot load the network types of the backend: %v", err)
+
+		registry = legend.NewRegistry()
+		registry.AddConfig(profile.Types)
+	}
+
+	return registry
+}
+
+func withUnused(entries []legend.Entry, types []legend.Type) []legend.Entry {
+	used := make(map[int]bool, len(entries))
+	for _, e := range entries {
+		used[e.Code] = true
+	}
+	for _, t := range types {
+		if !used[t.Code] {
+			entries = append(entries, legend.Entry{Type: t})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Code < entries[j].Code })
+
+	return entries
+}
+
+// queryTypes parses ?types, the type codes the nodes and links endpoints keep.
+// It returns nil when the query is missing.
+func queryTypes(c *fiber.Ctx) (map[int]bool, error) {
+	types, ok, err := parsers.QueryIntList(c, "types")
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return typeSet(types), nil
+}
+
+// filterNodes returns the nodes of the given types, or all of them when types is nil.
+func filterNodes(nodes gisapi.NodesData, types map[int]bool) gisapi.NodesData {
+	if types == nil {
+		return nodes
+	}
+
+	out := gisapi.NodesData{}
+	for _, n := range nodes {
+		if types[n.Type] {
+			out = append(out, n)
+		}
+	}
+
+	return out
+}
+
+// filterLinks returns the links of the given types, or all of them when types is nil.
+func filterLinks(links gisapi.LinksData, types map[int]bool) gisapi.LinksData {
+	if types == nil {
+		return links
+	}
+
+	out := gisapi.LinksData{}
+	for _, l := range links {
+		if types[l.Type] {
+			out = append(out, l)
+		}
+	}
+
+	return out
+}
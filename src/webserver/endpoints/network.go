@@ -4,15 +4,30 @@
 package endpoints
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
 	"github.com/gofiber/fiber/v2"
 
+	"github.com/teocci/go-hynix-3d-viewer/src/crs"
+	"github.com/teocci/go-hynix-3d-viewer/src/geojson"
 	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+	"github.com/teocci/go-hynix-3d-viewer/src/gltf"
+	"github.com/teocci/go-hynix-3d-viewer/src/linkchain"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/authz"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/parsers"
 	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
 )
 
+// The nodes, links and chains kinds accept ?crs and ?origin, see parseProjection.
+// Nodes and links are also sent in the wire encoding when the client asks for it,
+// and ?types keeps the elements of the listed type codes.
 const (
-	NetworkKindNodes = "nodes"
-	NetworkKindLinks = "links"
+	NetworkKindNodes  = "nodes"
+	NetworkKindLinks  = "links"
+	NetworkKindChains = "chains"
 )
 
 func NetworkHandler(c *fiber.Ctx) error {
@@ -20,12 +35,14 @@ func NetworkHandler(c *fiber.Ctx) error {
 	if kind == "" {
 		return renders.JSONBadRequest(c, ErrKindRequired)
 	}
-	
+
 	switch kind {
 	case NetworkKindNodes:
 		return NetworkNodes(c)
 	case NetworkKindLinks:
 		return NetworkLinks(c)
+	case NetworkKindChains:
+		return NetworkChains(c)
 	}
 
 	return renders.JSONBadRequest(c, ErrKindNotSupported)
@@ -37,12 +54,49 @@ func NetworkNodes(c *fiber.Ctx) error {
 		return renders.JSONBadRequest(c, ErrUUIDRequired)
 	}
 
-	list := &gisapi.NodesData{}
-	if err := list.ByNetworkUUID(uuid); err != nil {
-		return renders.JSONInternalError(c, err)
+	asGeoJSON := parsers.WantsGeoJSON(c)
+	proj, err := parseProjection(c, asGeoJSON)
+	if err != nil {
+		return renders.JSONBadRequest(c, err)
+	}
+
+	types, err := queryTypes(c)
+	if err != nil {
+		return renders.JSONBadRequest(c, err)
 	}
 
-	return renders.StreamResponse(c, renders.R{"uuid": uuid, "data": list})
+	if err := authz.Network(c, uuid, authz.ActionRead); err != nil {
+		return accessDenied(c, err)
+	}
+
+	if streams(c, proj, cacheKey(uuid, NetworkKindNodes)) {
+		return streamNodes(c, uuid, proj, asGeoJSON, types)
+	}
+
+	list, meta, err := cachedNodes(c.UserContext(), uuid)
+	if err != nil {
+		return upstreamError(c, err)
+	}
+	if notModified(c, meta) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+	list = filterNodes(list, types)
+
+	if parsers.WantsBinary(c) {
+		return sendNodesBinary(c, proj, list)
+	}
+
+	var t *crs.Transformer
+	if !proj.identity() {
+		t = proj.transformer(list, nil)
+		list = t.Nodes(list)
+	}
+
+	if asGeoJSON {
+		return renders.StreamResponseWithType(c, geojson.MIMEType, proj.featureCollection(geojson.FromNodes(list)))
+	}
+
+	return renders.StreamResponse(c, proj.response(uuid, t, list))
 }
 
 func NetworkLinks(c *fiber.Ctx) error {
@@ -51,10 +105,145 @@ func NetworkLinks(c *fiber.Ctx) error {
 		return renders.JSONBadRequest(c, ErrUUIDRequired)
 	}
 
-	list := &gisapi.LinksData{}
-	if err := list.ByNetworkUUID(uuid); err != nil {
+	asGeoJSON := parsers.WantsGeoJSON(c)
+	proj, err := parseProjection(c, asGeoJSON)
+	if err != nil {
+		return renders.JSONBadRequest(c, err)
+	}
+
+	types, err := queryTypes(c)
+	if err != nil {
+		return renders.JSONBadRequest(c, err)
+	}
+
+	if err := authz.Network(c, uuid, authz.ActionRead); err != nil {
+		return accessDenied(c, err)
+	}
+
+	if streams(c, proj, cacheKey(uuid, NetworkKindLinks)) {
+		return streamLinks(c, uuid, proj, asGeoJSON, types)
+	}
+
+	list, meta, err := cachedLinks(c.UserContext(), uuid)
+	if err != nil {
+		return upstreamError(c, err)
+	}
+	if notModified(c, meta) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+	list = filterLinks(list, types)
+
+	if parsers.WantsBinary(c) {
+		return sendLinksBinary(c, proj, list)
+	}
+
+	var t *crs.Transformer
+	if !proj.identity() {
+		t = proj.transformer(nil, list)
+		list = t.Links(list)
+	}
+
+	if asGeoJSON {
+		return renders.StreamResponseWithType(c, geojson.MIMEType, proj.featureCollection(geojson.FromLinks(list)))
+	}
+
+	return renders.StreamResponse(c, proj.response(uuid, t, list))
+}
+
+// NetworkChains merges the links of a network into polylines so the viewer does not have to.
+func NetworkChains(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	proj, err := parseProjection(c, false)
+	if err != nil {
+		return renders.JSONBadRequest(c, err)
+	}
+
+	if err := authz.Network(c, uuid, authz.ActionRead); err != nil {
+		return accessDenied(c, err)
+	}
+
+	list, meta, err := cachedLinks(c.UserContext(), uuid)
+	if err != nil {
+		return upstreamError(c, err)
+	}
+	if notModified(c, meta) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	// Chains are matched on the original coordinates and transformed afterwards.
+	chains := linkchain.Build(list)
+
+	var t *crs.Transformer
+	if !proj.identity() {
+		t = proj.transformer(nil, list)
+		for i := range chains {
+			chains[i].Geometry = t.Polyline(chains[i].Geometry)
+		}
+	}
+
+	return renders.StreamResponse(c, proj.response(uuid, t, chains))
+}
+
+// NetworkExportGLB exports the nodes and links of a network as a binary glTF file.
+// The nodes query selects how nodes are drawn: points (default) or mesh.
+func NetworkExportGLB(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	style := c.Query("nodes", gltf.NodeStylePoints)
+	if style != gltf.NodeStylePoints && style != gltf.NodeStyleMesh {
+		return renders.JSONBadRequest(c, ErrInvalidNodeStyle)
+	}
+
+	if err := authz.Network(c, uuid, authz.ActionRead); err != nil {
+		return accessDenied(c, err)
+	}
+
+	nodes, nodesMeta, err := cachedNodes(c.UserContext(), uuid)
+	if err != nil {
+		return upstreamError(c, err)
+	}
+
+	links, linksMeta, err := cachedLinks(c.UserContext(), uuid)
+	if err != nil {
+		return upstreamError(c, err)
+	}
+	if notModified(c, nodesMeta, linksMeta) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	var out bytes.Buffer
+	builder := gltf.NetworkGLB("network-"+uuid, nodes, links, gltf.Options{NodeStyle: style})
+	if err := builder.WriteGLB(&out); err != nil {
+		if errors.Is(err, gltf.ErrEmptyScene) {
+			return renders.JSONNotFound(c, err)
+		}
 		return renders.JSONInternalError(c, err)
 	}
 
-	return renders.StreamResponse(c, renders.R{"uuid": uuid, "data": list})
+	c.Set(fiber.HeaderContentType, gltf.MIMEType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="network-%s.glb"`, uuid))
+
+	return c.Send(out.Bytes())
+}
+
+// fetchNetwork fetches the nodes and links of a network through the geometry cache.
+func fetchNetwork(ctx context.Context, uuid string) (gisapi.NodesData, gisapi.LinksData, error) {
+	nodes, _, err := cachedNodes(ctx, uuid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	links, _, err := cachedLinks(ctx, uuid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return nodes, links, nil
 }
@@ -0,0 +1,160 @@
+// Package endpoints
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-13
+package endpoints
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/config"
+	"github.com/teocci/go-hynix-3d-viewer/src/db"
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+	"github.com/teocci/go-hynix-3d-viewer/src/memo"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/authz"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/parsers"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/session"
+)
+
+const (
+	catalogueTTL         = 5 * time.Minute
+	catalogueDefaultSize = 20
+	catalogueMaxSize     = 100
+)
+
+const (
+	NetworkSortName    = "name"
+	NetworkSortUUID    = "uuid"
+	NetworkSortNodes   = "nodes"
+	NetworkSortLinks   = "links"
+	NetworkSortUpdated = "updated"
+)
+
+// catalogues keeps the network list of each profile, which can be slow to build.
+var catalogues = memo.New[[]gisapi.NetworkInfo](catalogueTTL)
+
+// NetworkList lists the networks of the backend the user can read.
+// ?q matches the name or UUID, ?sort is one of the NetworkSort fields, prefixed with "-" for
+// descending order, and ?page and ?size select a page. An optional provider query narrows
+// the list to the networks registered under a provider.
+func NetworkList(c *fiber.Ctx) error {
+	user, ok := session.CurrentUser(c)
+	if !ok {
+		return renders.JSONUnauthorized(c, authz.ErrNotAuthenticated)
+	}
+
+	paging, err := parsers.QueryPaging(c, catalogueDefaultSize, catalogueMaxSize)
+	if err != nil {
+		return renders.JSONBadRequest(c, err)
+	}
+
+	field, desc, err := parsers.QuerySort(c, NetworkSortName,
+		NetworkSortName, NetworkSortUUID, NetworkSortNodes, NetworkSortLinks, NetworkSortUpdated)
+	if err != nil {
+		return renders.JSONBadRequest(c, err)
+	}
+
+	var providerUUIDs []string
+	if provider, err := parsers.QueryProvider(c); err == nil {
+		if err := authz.Provider(c, provider, authz.ActionRead); err != nil {
+			return accessDenied(c, err)
+		}
+		providerUUIDs = []string{provider}
+	} else if !user.IsAdmin() {
+		providers, err := db.GetProvidersByUserUUID(user.UUID)
+		if err != nil {
+			return renders.JSONInternalError(c, ErrFailedToLoadNetworks)
+		}
+		providerUUIDs = make([]string, len(providers))
+		for i, p := range providers {
+			providerUUIDs[i] = p.UUID
+		}
+	}
+
+	var allowed map[string]bool
+	if providerUUIDs != nil {
+		uuids, err := db.ListResourceUUIDs(db.ResourceNetwork, providerUUIDs)
+		if err != nil {
+			return renders.JSONInternalError(c, ErrFailedToLoadNetworks)
+		}
+		allowed = make(map[string]bool, len(uuids))
+		for _, uuid := range uuids {
+			allowed[uuid] = true
+		}
+	}
+
+	all, err := networkCatalogue(c.UserContext())
+	if err != nil {
+		return upstreamError(c, err)
+	}
+
+	search := strings.ToLower(c.Query("q"))
+	list := []gisapi.NetworkInfo{}
+	for _, info := range all {
+		if allowed != nil && !allowed[info.UUID] {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(info.Name), search) &&
+			!strings.Contains(strings.ToLower(info.UUID), search) {
+			continue
+		}
+		list = append(list, info)
+	}
+
+	sortNetworks(list, field, desc)
+	start, end := paging.Slice(len(list))
+
+	return renders.JSONOKResponse(c, renders.R{
+		"data":  list[start:end],
+		"total": len(list),
+		"page":  paging.Page,
+		"size":  paging.Size,
+	})
+}
+
+func networkCatalogue(ctx context.Context) ([]gisapi.NetworkInfo, error) {
+	return profileCatalogue(ctx, config.Get().Profile)
+}
+
+// profileCatalogue returns the network list of any configured profile.
+func profileCatalogue(ctx context.Context, profile string) ([]gisapi.NetworkInfo, error) {
+	return catalogues.Get(profile, func() ([]gisapi.NetworkInfo, error) {
+		source, err := gisapi.ProfileSource(profile)
+		if err != nil {
+			return nil, err
+		}
+		return source.Networks(context.WithoutCancel(ctx))
+	})
+}
+
+// sortNetworks sorts in place; ties are broken by UUID so pages are stable.
+func sortNetworks(list []gisapi.NetworkInfo, field string, desc bool) {
+	slices.SortStableFunc(list, func(a, b gisapi.NetworkInfo) int {
+		var order int
+		switch field {
+		case NetworkSortUUID:
+			order = strings.Compare(a.UUID, b.UUID)
+		case NetworkSortNodes:
+			order = a.NodeCount - b.NodeCount
+		case NetworkSortLinks:
+			order = a.LinkCount - b.LinkCount
+		case NetworkSortUpdated:
+			order = a.UpdatedAt.Compare(b.UpdatedAt)
+		default:
+			order = strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		}
+		if order == 0 {
+			order = strings.Compare(a.UUID, b.UUID)
+		}
+		if desc {
+			return -order
+		}
+
+		return order
+	})
+}
@@ -0,0 +1,131 @@
+// Package endpoints
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-07
+package endpoints
+
+import (
+	"math"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/config"
+	"github.com/teocci/go-hynix-3d-viewer/src/crs"
+	"github.com/teocci/go-hynix-3d-viewer/src/geojson"
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/parsers"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
+)
+
+const OriginAuto = "auto"
+
+// projection holds how network geometry is returned: in the ?crs system and, with ?origin,
+// relative to a local origin. ?origin=auto picks the center of the network bounds;
+// ?origin=x,y,z reuses an origin given by an earlier response.
+type projection struct {
+	src, dst crs.CRS
+	origin   []float64
+	auto     bool
+}
+
+// parseProjection reads ?crs and ?origin. GeoJSON defaults to WGS84 as RFC 7946 requires
+// when the CRS of the profile is known.
+func parseProjection(c *fiber.Ctx, asGeoJSON bool) (*projection, error) {
+	p := &projection{}
+
+	if code := config.ActiveProfile().CRS; code != "" {
+		src, err := crs.Parse(code)
+		if err != nil {
+			return nil, err
+		}
+		p.src = src
+	}
+
+	if code := c.Query("crs"); code != "" {
+		dst, err := crs.Parse(code)
+		if err != nil {
+			return nil, err
+		}
+		if p.src == nil {
+			return nil, ErrSourceCRSUnknown
+		}
+		p.dst = dst
+	} else if asGeoJSON && p.src != nil {
+		p.dst, _ = crs.ByCode(crs.CodeWGS84)
+	}
+
+	if c.Query("origin") == OriginAuto {
+		p.auto = true
+		return p, nil
+	}
+	origin, _, err := parsers.QueryFloatList(c, "origin", 3)
+	if err != nil {
+		return nil, ErrInvalidOrigin
+	}
+	p.origin = origin
+
+	return p, nil
+}
+
+// transformer prepares the transformation of the network geometry.
+func (p *projection) transformer(nodes gisapi.NodesData, links gisapi.LinksData) *crs.Transformer {
+	t := crs.NewTransformer(p.src, p.dst)
+	if p.auto {
+		// Any point close to the data works as an origin, so the center is
+		// computed before reprojecting and only moved into the target system.
+		origin := t.Position(crs.LocalOrigin(nodes, links))
+		for i := range origin {
+			origin[i] = math.Round(origin[i])
+		}
+		return t.WithOrigin(origin)
+	}
+
+	return t.WithOrigin(p.origin)
+}
+
+// absoluteOrigin returns the ?origin query, or the rounded center of the data in the target system.
+func (p *projection) absoluteOrigin(nodes gisapi.NodesData, links gisapi.LinksData) []float64 {
+	if p.origin != nil {
+		return p.origin
+	}
+
+	auto := *p
+	auto.auto = true
+
+	return auto.transformer(nodes, links).Origin()
+}
+
+// target returns the CRS of the returned geometry, or nil when unknown.
+func (p *projection) target() crs.CRS {
+	if p.dst != nil {
+		return p.dst
+	}
+
+	return p.src
+}
+
+// identity reports whether the geometry is returned as the backend sent it.
+func (p *projection) identity() bool {
+	return (p.dst == nil || p.src.Code() == p.dst.Code()) && p.origin == nil && !p.auto
+}
+
+// response wraps data with the uuid and, when known, the CRS and local origin.
+func (p *projection) response(uuid string, t *crs.Transformer, data any) renders.R {
+	res := renders.R{"uuid": uuid, "data": data}
+	if target := p.target(); target != nil {
+		res["crs"] = crs.Identifier(target)
+	}
+	if t != nil && t.Origin() != nil {
+		res["origin"] = t.Origin()
+	}
+
+	return res
+}
+
+// featureCollection names the CRS of a GeoJSON export unless it is WGS84.
+func (p *projection) featureCollection(fc geojson.FeatureCollection) geojson.FeatureCollection {
+	if target := p.target(); target != nil && target.Code() != crs.CodeWGS84 {
+		fc.CRS = geojson.NewNamedCRS(target.Code())
+	}
+
+	return fc
+}
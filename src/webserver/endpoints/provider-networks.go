@@ -0,0 +1,101 @@
+// Package endpoints
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-22
+package endpoints
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/db"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/authz"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/requests"
+)
+
+// ProviderNetworks lists the UUIDs of the networks registered under a provider.
+// Users granted the provider can read these networks.
+func ProviderNetworks(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	if err := authz.Provider(c, uuid, authz.ActionRead); err != nil {
+		return accessDenied(c, err)
+	}
+
+	networks, err := db.ListResourceUUIDs(db.ResourceNetwork, []string{uuid})
+	if err != nil {
+		return renders.JSONInternalError(c, err)
+	}
+
+	return renders.JSONDataResponse(c, networks)
+}
+
+// ProviderNetworkRegister registers networks under a provider. Networks already registered
+// are left as they are.
+func ProviderNetworkRegister(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	if err := authz.Provider(c, uuid, authz.ActionAdmin); err != nil {
+		return accessDenied(c, err)
+	}
+
+	var req requests.ProviderNetworksRequest
+	if err := c.BodyParser(&req); err != nil {
+		return renders.JSONBadRequest(c, ErrInvalidPayload)
+	}
+
+	networks := make([]string, 0, len(req.Networks))
+	for _, network := range req.Networks {
+		if network = strings.TrimSpace(network); network != "" {
+			networks = append(networks, network)
+		}
+	}
+	if len(networks) == 0 {
+		return renders.JSONBadRequest(c, ErrNetworksRequired)
+	}
+
+	if _, err := db.GetProviderDetails(uuid); err != nil {
+		return providerError(c, err)
+	}
+
+	registered := []string{}
+	for _, network := range networks {
+		err := db.RegisterProviderResource(uuid, db.ResourceNetwork, network)
+		if errors.Is(err, db.ErrExists) {
+			continue
+		}
+		if err != nil {
+			return renders.JSONInternalError(c, err)
+		}
+		registered = append(registered, network)
+	}
+
+	return renders.JSONResponse(c, fiber.StatusCreated, fiber.Map{"data": fiber.Map{"registered": registered}})
+}
+
+// ProviderNetworkUnregister removes a network from a provider.
+func ProviderNetworkUnregister(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	network := c.Params("network")
+	if uuid == "" || network == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	if err := authz.Provider(c, uuid, authz.ActionAdmin); err != nil {
+		return accessDenied(c, err)
+	}
+
+	if err := db.UnregisterProviderResource(uuid, db.ResourceNetwork, network); err != nil {
+		return providerError(c, err)
+	}
+
+	return renders.JSONSuccessResponse(c)
+}
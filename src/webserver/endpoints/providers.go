@@ -0,0 +1,244 @@
+// Package endpoints
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-21
+package endpoints
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/db"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/authz"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/requests"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/session"
+)
+
+// ProviderInfo is the public representation of a provider. Credentials are never returned.
+type ProviderInfo struct {
+	UUID           string    `json:"uuid"`
+	Name           string    `json:"name"`
+	Host           string    `json:"host"`
+	Port           int       `json:"port"`
+	HasCredentials bool      `json:"hasCredentials"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type ProviderInfos []ProviderInfo
+
+func asProviderInfo(p db.Provider) ProviderInfo {
+	return ProviderInfo{
+		UUID:           p.UUID,
+		Name:           p.Name,
+		Host:           p.Host,
+		Port:           p.Port,
+		HasCredentials: p.DBUser != "" || p.DBPassword != "",
+		CreatedAt:      p.CreatedAt,
+		UpdatedAt:      p.UpdatedAt,
+	}
+}
+
+// ProviderList returns the providers linked to the current user.
+func ProviderList(c *fiber.Ctx) error {
+	providers, err := db.GetProvidersByUserUUID(session.CurrentUserUUID(c))
+	if err != nil {
+		return renders.JSONInternalError(c, err)
+	}
+
+	list := make(ProviderInfos, len(providers))
+	for i, p := range providers {
+		list[i] = asProviderInfo(p)
+	}
+
+	return renders.JSONDataResponse(c, list)
+}
+
+func ProviderDetails(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	if err := authz.Provider(c, uuid, authz.ActionRead); err != nil {
+		return accessDenied(c, err)
+	}
+
+	provider, err := db.GetProviderDetails(uuid)
+	if err != nil {
+		return providerError(c, err)
+	}
+
+	return renders.JSONDataResponse(c, asProviderInfo(provider))
+}
+
+// ProviderCreate registers a provider and grants its creator full access to it.
+func ProviderCreate(c *fiber.Ctx) error {
+	if err := authz.RequireRole(c, db.RoleEditor); err != nil {
+		return accessDenied(c, err)
+	}
+
+	var req requests.ProviderCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return renders.JSONBadRequest(c, ErrInvalidPayload)
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	req.Host = strings.TrimSpace(req.Host)
+	if req.Name == "" {
+		return renders.JSONBadRequest(c, ErrNameRequired)
+	}
+	if req.Host == "" {
+		return renders.JSONBadRequest(c, ErrHostRequired)
+	}
+	if !validPort(req.Port) {
+		return renders.JSONBadRequest(c, ErrInvalidPort)
+	}
+
+	provider, err := db.RegisterNewProvider(session.CurrentUserUUID(c), req.Name, req.Host, req.Port, req.DBUser, req.DBPassword)
+	if err != nil {
+		return providerError(c, err)
+	}
+
+	return renders.JSONResponse(c, fiber.StatusCreated, fiber.Map{"data": asProviderInfo(provider)})
+}
+
+func ProviderUpdate(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	if err := authz.Provider(c, uuid, authz.ActionWrite); err != nil {
+		return accessDenied(c, err)
+	}
+
+	var req requests.ProviderUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return renders.JSONBadRequest(c, ErrInvalidPayload)
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return renders.JSONBadRequest(c, ErrNameRequired)
+		}
+		req.Name = &name
+	}
+	if req.Host != nil {
+		host := strings.TrimSpace(*req.Host)
+		if host == "" {
+			return renders.JSONBadRequest(c, ErrHostRequired)
+		}
+		req.Host = &host
+	}
+	if req.Port != nil && !validPort(*req.Port) {
+		return renders.JSONBadRequest(c, ErrInvalidPort)
+	}
+
+	provider, err := db.UpdateProvider(uuid, db.ProviderUpdate{
+		Name:       req.Name,
+		Host:       req.Host,
+		Port:       req.Port,
+		DBUser:     req.DBUser,
+		DBPassword: req.DBPassword,
+	})
+	if err != nil {
+		return providerError(c, err)
+	}
+
+	return renders.JSONDataResponse(c, asProviderInfo(provider))
+}
+
+func ProviderDelete(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	if err := authz.Provider(c, uuid, authz.ActionAdmin); err != nil {
+		return accessDenied(c, err)
+	}
+
+	if err := db.DeleteProvider(uuid); err != nil {
+		return providerError(c, err)
+	}
+
+	return renders.JSONSuccessResponse(c)
+}
+
+// ProviderLink grants a user access to a provider. The granted role cannot exceed the caller's own.
+func ProviderLink(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	if err := authz.Provider(c, uuid, authz.ActionWrite); err != nil {
+		return accessDenied(c, err)
+	}
+
+	var req requests.ProviderLinkRequest
+	if err := c.BodyParser(&req); err != nil {
+		return renders.JSONBadRequest(c, ErrInvalidPayload)
+	}
+	if req.UserUUID == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	role := db.RoleViewer
+	if req.Role != "" {
+		parsed, ok := db.ParseRole(req.Role)
+		if !ok {
+			return renders.JSONBadRequest(c, db.ErrInvalidRole)
+		}
+		role = parsed
+	}
+
+	var action authz.Action
+	switch role {
+	case db.RoleAdmin:
+		action = authz.ActionAdmin
+	case db.RoleEditor:
+		action = authz.ActionWrite
+	default:
+		action = authz.ActionRead
+	}
+	if err := authz.Provider(c, uuid, action); err != nil {
+		return renders.JSONForbidden(c, ErrRoleAboveGrant)
+	}
+
+	if _, err := db.GetProviderDetails(uuid); err != nil {
+		return providerError(c, err)
+	}
+	if _, err := db.GetUserByUUID(req.UserUUID); err != nil {
+		return userError(c, err)
+	}
+
+	if err := db.LinkProviderWithUserRole(req.UserUUID, uuid, role); err != nil {
+		return providerError(c, err)
+	}
+
+	return renders.JSONSuccessResponse(c)
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
+
+// providerError maps provider service errors to response codes.
+func providerError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		return renders.JSONNotFound(c, err)
+	case errors.Is(err, db.ErrProviderAlreadyLinked):
+		return renders.JSONConflict(c, err)
+	case errors.Is(err, db.ErrMasterKeyMissing):
+		return renders.JSONServiceUnavailable(c, err)
+	default:
+		return renders.JSONInternalError(c, err)
+	}
+}
@@ -0,0 +1,126 @@
+// Package endpoints
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-03
+package endpoints
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/config"
+	"github.com/teocci/go-hynix-3d-viewer/src/memo"
+	"github.com/teocci/go-hynix-3d-viewer/src/spatial"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/authz"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/parsers"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
+)
+
+const (
+	QueryModeBBox    = "bbox"
+	QueryModeRadius  = "radius"
+	QueryModeNearest = "nearest"
+
+	spatialIndexTTL   = 10 * time.Minute
+	spatialIndexLimit = 8
+	maxNearest        = 1000
+)
+
+// spatialIndexes keeps the R-trees of the most recently queried networks, keyed by networkKey.
+var spatialIndexes = memo.NewLimited[*spatial.Index](spatialIndexTTL, spatialIndexLimit)
+
+// NetworkQuery returns the nodes and links of a network inside a region.
+//   - mode=bbox: ?bbox=minX,minY,minZ,maxX,maxY,maxZ
+//   - mode=radius: ?center=x,y,z&radius=r
+//   - mode=nearest: ?point=x,y,z&k=1, optionally limited by ?maxDistance
+//
+// ?kind=nodes or ?kind=links restricts the search to one kind of element.
+func NetworkQuery(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	var kinds []spatial.Kind
+	switch c.Query("kind") {
+	case "":
+	case NetworkKindNodes:
+		kinds = append(kinds, spatial.KindNode)
+	case NetworkKindLinks:
+		kinds = append(kinds, spatial.KindLink)
+	default:
+		return renders.JSONBadRequest(c, ErrKindNotSupported)
+	}
+
+	query, err := spatialQuery(c, kinds)
+	if err != nil {
+		return renders.JSONBadRequest(c, err)
+	}
+
+	if err := authz.Network(c, uuid, authz.ActionRead); err != nil {
+		return accessDenied(c, err)
+	}
+
+	index, err := networkIndex(c.UserContext(), uuid)
+	if err != nil {
+		return upstreamError(c, err)
+	}
+
+	return renders.StreamResponse(c, renders.R{"uuid": uuid, "data": query(index)})
+}
+
+// spatialQuery validates the query parameters of the selected mode.
+func spatialQuery(c *fiber.Ctx, kinds []spatial.Kind) (func(*spatial.Index) spatial.Result, error) {
+	switch c.Query("mode", QueryModeBBox) {
+	case QueryModeBBox:
+		bbox, ok, err := parsers.QueryFloatList(c, "bbox", 6)
+		if err != nil || !ok {
+			return nil, ErrInvalidBBox
+		}
+		box := spatial.Box{Min: spatial.PointFrom(bbox[:3]), Max: spatial.PointFrom(bbox[3:])}
+		return func(idx *spatial.Index) spatial.Result { return idx.InBox(box, kinds...) }, nil
+
+	case QueryModeRadius:
+		center, ok, err := parsers.QueryFloatList(c, "center", 3)
+		if err != nil || !ok {
+			return nil, ErrInvalidPoint
+		}
+		radius := c.QueryFloat("radius")
+		if radius <= 0 {
+			return nil, ErrInvalidRadius
+		}
+		return func(idx *spatial.Index) spatial.Result {
+			return idx.InSphere(spatial.PointFrom(center), radius, kinds...)
+		}, nil
+
+	case QueryModeNearest:
+		point, ok, err := parsers.QueryFloatList(c, "point", 3)
+		if err != nil || !ok {
+			return nil, ErrInvalidPoint
+		}
+		k := c.QueryInt("k", 1)
+		if k < 1 || k > maxNearest {
+			return nil, ErrInvalidNearestCount
+		}
+		maxDistance := c.QueryFloat("maxDistance")
+		return func(idx *spatial.Index) spatial.Result {
+			return idx.Nearest(spatial.PointFrom(point), k, maxDistance, kinds...)
+		}, nil
+	}
+
+	return nil, ErrQueryModeNotSupported
+}
+
+// networkIndex returns the cached spatial index of a network, building it when needed.
+// The build is shared with other callers, so it is not cancelled with the request.
+func networkIndex(ctx context.Context, uuid string) (*spatial.Index, error) {
+	return spatialIndexes.Get(networkKey(config.Get().Profile, uuid), func() (*spatial.Index, error) {
+		nodes, links, err := fetchNetwork(context.WithoutCancel(ctx), uuid)
+		if err != nil {
+			return nil, err
+		}
+
+		return spatial.New(nodes, links), nil
+	})
+}
@@ -0,0 +1,146 @@
+// Package endpoints
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-16
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/config"
+	"github.com/teocci/go-hynix-3d-viewer/src/crs"
+	"github.com/teocci/go-hynix-3d-viewer/src/geocache"
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+	"github.com/teocci/go-hynix-3d-viewer/src/scene"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/authz"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/parsers"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
+)
+
+const maxSceneNetworks = 16
+
+// NetworkScene merges several networks, possibly of different profiles, into one scene.
+// ?networks lists them as uuid, for the active profile, or profile:uuid. ?crs picks the
+// system of the scene, the one of the first network by default, and ?geometry=false
+// returns the manifest without the nodes and links.
+func NetworkScene(c *fiber.Ctx) error {
+	refs, err := querySceneRefs(c)
+	if err != nil {
+		return renders.JSONBadRequest(c, err)
+	}
+
+	var target crs.CRS
+	if code := c.Query("crs"); code != "" {
+		if target, err = crs.Parse(code); err != nil {
+			return renders.JSONBadRequest(c, err)
+		}
+	}
+
+	networks := make([]scene.Network, len(refs))
+	for i, ref := range refs {
+		if err := authz.ProfileNetwork(c, ref.Profile, ref.UUID, authz.ActionRead); err != nil {
+			return accessDenied(c, err)
+		}
+
+		networks[i].Ref = ref
+		if networks[i].CRS, err = profileCRS(ref.Profile); err != nil {
+			return renders.JSONBadRequest(c, err)
+		}
+	}
+
+	metas, err := fetchScene(c.UserContext(), networks)
+	if err != nil {
+		return upstreamError(c, err)
+	}
+	if notModified(c, metas...) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	s, err := scene.Build(networks, target)
+	if err != nil {
+		return renders.JSONBadRequest(c, err)
+	}
+	if !c.QueryBool("geometry", true) {
+		s.WithoutGeometry()
+	}
+
+	return renders.StreamResponse(c, renders.R{"data": s})
+}
+
+// querySceneRefs parses ?networks, dropping repeated networks.
+func querySceneRefs(c *fiber.Ctx) ([]scene.Ref, error) {
+	param := c.Query("networks")
+	if param == "" {
+		return nil, ErrNetworksRequired
+	}
+
+	var refs []scene.Ref
+	seen := map[scene.Ref]bool{}
+	for _, s := range parsers.SplitAndTrim(param, ",") {
+		ref, err := scene.ParseRef(s, config.Get().Profile)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+	if len(refs) > maxSceneNetworks {
+		return nil, ErrTooManyNetworks
+	}
+
+	return refs, nil
+}
+
+// profileCRS returns the CRS declared by a profile, or nil when it declares none.
+func profileCRS(name string) (crs.CRS, error) {
+	profile, ok := config.Get().Profiles[name]
+	if !ok {
+		return nil, gisapi.ErrorUnknownProfile(name)
+	}
+	if profile.CRS == "" {
+		return nil, nil
+	}
+
+	return crs.Parse(profile.CRS)
+}
+
+// fetchScene fetches the geometry of every network concurrently through the geometry cache,
+// and its name from the catalogue of its profile. A network missing from the catalogue is
+// still part of the scene.
+func fetchScene(ctx context.Context, networks []scene.Network) ([]geocache.Meta, error) {
+	metas := make([]geocache.Meta, 2*len(networks))
+	errs := make([]error, len(networks))
+
+	var wg sync.WaitGroup
+	for i := range networks {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			n := &networks[i]
+			var nodesErr, linksErr error
+			n.Nodes, metas[2*i], nodesErr = profileNodes(ctx, n.Profile, n.UUID)
+			n.Links, metas[2*i+1], linksErr = profileLinks(ctx, n.Profile, n.UUID)
+			errs[i] = errors.Join(nodesErr, linksErr)
+
+			list, err := profileCatalogue(ctx, n.Profile)
+			if err != nil {
+				log.Printf("Cannot read the networks of profile %s: %v", n.Profile, err)
+			}
+			for _, info := range list {
+				if info.UUID == n.UUID {
+					n.Name = info.Name
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	return metas, errors.Join(errs...)
+}
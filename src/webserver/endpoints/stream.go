@@ -0,0 +1,117 @@
+// Package endpoints
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-11
+package endpoints
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/config"
+	"github.com/teocci/go-hynix-3d-viewer/src/crs"
+	"github.com/teocci/go-hynix-3d-viewer/src/geocache"
+	"github.com/teocci/go-hynix-3d-viewer/src/geojson"
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/parsers"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
+)
+
+// streams reports whether a nodes or links request is streamed from the backend instead of
+// served from the cache. Only uncached networks are streamed, and only in encodings that can
+// be written one element at a time: ?origin=auto and the wire encoding need the whole list.
+func streams(c *fiber.Ctx, proj *projection, key geocache.Key) bool {
+	if !config.Get().Cache.Stream || proj.auto || parsers.WantsBinary(c) {
+		return false
+	}
+
+	return !geocache.Default().Cached(key)
+}
+
+// streamNodes copies the nodes of a network from the backend to the client as they are decoded.
+// Types filters the nodes like filterNodes.
+func streamNodes(c *fiber.Ctx, uuid string, proj *projection, asGeoJSON bool, types map[int]bool) error {
+	return streamNetwork(c, uuid, proj, asGeoJSON, gisapi.OpenNodes, func(t *crs.Transformer, n gisapi.NodeGeometry) (any, bool) {
+		if types != nil && !types[n.Type] {
+			return nil, false
+		}
+		if t != nil {
+			n.Geometry = t.Position(n.Geometry)
+		}
+		if asGeoJSON {
+			return geojson.NodeFeature(n), true
+		}
+
+		return n, true
+	})
+}
+
+// streamLinks copies the links of a network like streamNodes.
+func streamLinks(c *fiber.Ctx, uuid string, proj *projection, asGeoJSON bool, types map[int]bool) error {
+	return streamNetwork(c, uuid, proj, asGeoJSON, gisapi.OpenLinks, func(t *crs.Transformer, l gisapi.LinkGeometry) (any, bool) {
+		if types != nil && !types[l.Type] {
+			return nil, false
+		}
+		if t != nil {
+			l.Geometry = t.Polyline(l.Geometry)
+		}
+		if asGeoJSON {
+			return geojson.LinkFeature(l), true
+		}
+
+		return l, true
+	})
+}
+
+// streamNetwork opens the upstream stream before answering, so failures to reach the backend
+// get a proper status. A failure in the middle of the data truncates the response.
+// The elements convert rejects are skipped.
+func streamNetwork[T any](
+	c *fiber.Ctx,
+	uuid string,
+	proj *projection,
+	asGeoJSON bool,
+	open func(context.Context, string) (*gisapi.Stream[T], error),
+	convert func(*crs.Transformer, T) (any, bool),
+) error {
+	// The body is written after the handler returns, when the request context is done.
+	ctx, cancel := context.WithCancel(context.WithoutCancel(c.UserContext()))
+
+	stream, err := open(ctx, uuid)
+	if err != nil {
+		cancel()
+		return upstreamError(c, err)
+	}
+
+	var t *crs.Transformer
+	if !proj.identity() {
+		t = proj.transformer(nil, nil)
+	}
+
+	contentType, head, key := fiber.MIMEApplicationJSON, proj.response(uuid, t, nil), "data"
+	delete(head, "data")
+	if asGeoJSON {
+		fc := proj.featureCollection(geojson.NewFeatureCollection(""))
+		contentType, head, key = geojson.MIMEType, renders.R{"type": fc.Type}, "features"
+		if fc.CRS != nil {
+			head["crs"] = fc.CRS
+		}
+	}
+
+	return renders.StreamArray(c, contentType, head, key, func(emit func(any) error) error {
+		defer cancel()
+		defer stream.Close()
+
+		var v T
+		for stream.Next(&v) {
+			if out, ok := convert(t, v); ok {
+				if err := emit(out); err != nil {
+					return err
+				}
+			}
+			v = *new(T)
+		}
+
+		return stream.Err()
+	})
+}
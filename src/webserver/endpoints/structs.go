@@ -3,35 +3,19 @@
 // Author: teocci@yandex.com on 2025-2월-10
 package endpoints
 
+import "github.com/teocci/go-hynix-3d-viewer/src/gisdata"
+
 // GISPoint represents a single point with an ID and coordinates.
-type GISPoint struct {
-	Id          string `json:"id"`
-	Coordinates []int  `json:"coordinates"`
-}
+type GISPoint = gisdata.GISPoint
 
 // GISLine represents a line connecting two points by their IDs.
-type GISLine struct {
-	Id    string `json:"id"`
-	Start string `json:"start"`
-	End   string `json:"end"`
-}
+type GISLine = gisdata.GISLine
 
 // GISPolyline represents a sequence of connected points (nodes).
-type GISPolyline struct {
-	Id    string   `json:"id"`
-	Nodes []string `json:"nodes"`
-}
+type GISPolyline = gisdata.GISPolyline
 
 // GISPolygon represents a closed shape defined by its vertices.
-type GISPolygon struct {
-	Id       string  `json:"id"`
-	Vertices [][]int `json:"vertices"`
-}
+type GISPolygon = gisdata.GISPolygon
 
 // GISData is the main struct that aggregates all the data types.
-type GISData struct {
-	Points    []GISPoint    `json:"points"`
-	Lines     []GISLine     `json:"lines"`
-	Polylines []GISPolyline `json:"polylines"`
-	Polygons  []GISPolygon  `json:"polygons"`
-}
+type GISData = gisdata.GISData
@@ -0,0 +1,106 @@
+// Package endpoints
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-04
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/config"
+	"github.com/teocci/go-hynix-3d-viewer/src/memo"
+	"github.com/teocci/go-hynix-3d-viewer/src/tiles"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/authz"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
+)
+
+const (
+	tilesetTTL   = 10 * time.Minute
+	tilesetLimit = 8
+)
+
+// tilesets keeps the octrees of the most recently viewed networks, keyed by networkKey.
+var tilesets = memo.NewLimited[*tiles.Tileset](tilesetTTL, tilesetLimit)
+
+// NetworkTileset returns the manifest of the octree tiles of a network.
+func NetworkTileset(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	if err := authz.Network(c, uuid, authz.ActionRead); err != nil {
+		return accessDenied(c, err)
+	}
+
+	tileset, err := networkTileset(c.UserContext(), uuid)
+	if err != nil {
+		return upstreamError(c, err)
+	}
+
+	return renders.StreamResponse(c, renders.R{"uuid": uuid, "data": tileset.Manifest()})
+}
+
+// NetworkTile returns one tile: simplified geometry at coarse levels, full geometry at the deepest one.
+func NetworkTile(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	addr, err := tileAddress(c)
+	if err != nil {
+		return renders.JSONBadRequest(c, err)
+	}
+
+	if err := authz.Network(c, uuid, authz.ActionRead); err != nil {
+		return accessDenied(c, err)
+	}
+
+	tileset, err := networkTileset(c.UserContext(), uuid)
+	if err != nil {
+		return upstreamError(c, err)
+	}
+
+	tile, err := tileset.Tile(addr)
+	if errors.Is(err, tiles.ErrTileNotFound) {
+		return renders.JSONNotFound(c, err)
+	}
+	if err != nil {
+		return renders.JSONInternalError(c, err)
+	}
+
+	return renders.StreamResponse(c, renders.R{"uuid": uuid, "data": tile})
+}
+
+func tileAddress(c *fiber.Ctx) (tiles.Address, error) {
+	var addr tiles.Address
+	for _, p := range []struct {
+		name  string
+		value *int
+	}{{"level", &addr.Level}, {"x", &addr.X}, {"y", &addr.Y}, {"z", &addr.Z}} {
+		v, err := c.ParamsInt(p.name)
+		if err != nil || v < 0 {
+			return addr, ErrInvalidTileAddress
+		}
+		*p.value = v
+	}
+
+	return addr, nil
+}
+
+// networkTileset returns the cached tileset of a network, building it when needed.
+// The build is shared with other callers, so it is not cancelled with the request.
+func networkTileset(ctx context.Context, uuid string) (*tiles.Tileset, error) {
+	return tilesets.Get(networkKey(config.Get().Profile, uuid), func() (*tiles.Tileset, error) {
+		nodes, links, err := fetchNetwork(context.WithoutCancel(ctx), uuid)
+		if err != nil {
+			return nil, err
+		}
+
+		return tiles.Build(nodes, links, tiles.Options{}), nil
+	})
+}
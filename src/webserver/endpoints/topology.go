@@ -0,0 +1,81 @@
+// Package endpoints
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-01
+package endpoints
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/topology"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/authz"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
+)
+
+const (
+	TopologyComponents = "components"
+	TopologyIsolated   = "isolated"
+	TopologyDangling   = "dangling"
+	TopologyDuplicates = "duplicates"
+	TopologyDegrees    = "degrees"
+	TopologyCycles     = "cycles"
+
+	defaultCycleLimit = 100
+)
+
+// NetworkTopology returns a summary of the network graph, or a single analysis when one is named.
+// The cycles analysis lists at most ?limit cycles; zero lists them all.
+func NetworkTopology(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	analysis := c.Params("analysis")
+	switch analysis {
+	case "", TopologyComponents, TopologyIsolated, TopologyDangling, TopologyDuplicates, TopologyDegrees,
+		TopologyCycles:
+	default:
+		return renders.JSONBadRequest(c, ErrAnalysisNotSupported)
+	}
+
+	if err := authz.Network(c, uuid, authz.ActionRead); err != nil {
+		return accessDenied(c, err)
+	}
+
+	graph, err := networkGraph(c.UserContext(), uuid)
+	if err != nil {
+		return upstreamError(c, err)
+	}
+
+	var data any
+	switch analysis {
+	case TopologyComponents:
+		data = graph.Components()
+	case TopologyIsolated:
+		data = graph.IsolatedNodes()
+	case TopologyDangling:
+		data = graph.DanglingLinks()
+	case TopologyDuplicates:
+		data = graph.DuplicateNodes()
+	case TopologyDegrees:
+		data = graph.DegreeHistogram()
+	case TopologyCycles:
+		data = graph.Cycles(c.QueryInt("limit", defaultCycleLimit))
+	default:
+		data = graph.Summary()
+	}
+
+	return renders.StreamResponse(c, renders.R{"uuid": uuid, "data": data})
+}
+
+// networkGraph fetches the nodes and links of a network and builds its graph.
+func networkGraph(ctx context.Context, uuid string) (*topology.Graph, error) {
+	nodes, links, err := fetchNetwork(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return topology.New(nodes, links), nil
+}
@@ -0,0 +1,87 @@
+// Package endpoints
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-02
+package endpoints
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/config"
+	"github.com/teocci/go-hynix-3d-viewer/src/topology"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/authz"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/parsers"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
+)
+
+// NetworkTrace follows the network from the ?from node id.
+// With ?to it returns the shortest path by link length between both nodes.
+// Otherwise it traces ?direction (downstream by default, upstream or both) and stops at
+// nodes whose type is a barrier: ?barriers overrides the types configured for the profile.
+func NetworkTrace(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	from := c.QueryInt("from", -1)
+	to := c.QueryInt("to", -1)
+	if from < 0 || (c.Query("to") != "" && to < 0) {
+		return renders.JSONBadRequest(c, ErrInvalidNodeId)
+	}
+
+	direction := topology.Direction(c.Query("direction", string(topology.Downstream)))
+	switch direction {
+	case topology.Downstream, topology.Upstream, topology.Both:
+	default:
+		return renders.JSONBadRequest(c, ErrInvalidDirection)
+	}
+
+	barriers, ok, err := parsers.QueryIntList(c, "barriers")
+	if err != nil {
+		return renders.JSONBadRequest(c, err)
+	}
+	if !ok {
+		barriers = config.ActiveProfile().Trace.BarrierTypes
+	}
+
+	if err := authz.Network(c, uuid, authz.ActionRead); err != nil {
+		return accessDenied(c, err)
+	}
+
+	graph, err := networkGraph(c.UserContext(), uuid)
+	if err != nil {
+		return upstreamError(c, err)
+	}
+
+	var data any
+	if to >= 0 {
+		data, err = graph.ShortestPath(from, to)
+	} else {
+		data, err = graph.Trace(from, direction, typeSet(barriers))
+	}
+	if err != nil {
+		return traceError(c, err)
+	}
+
+	return renders.StreamResponse(c, renders.R{"uuid": uuid, "data": data})
+}
+
+func traceError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, topology.ErrNodeNotFound), errors.Is(err, topology.ErrNoPath):
+		return renders.JSONNotFound(c, err)
+	default:
+		return renders.JSONInternalError(c, err)
+	}
+}
+
+func typeSet(types []int) map[int]bool {
+	set := make(map[int]bool, len(types))
+	for _, t := range types {
+		set[t] = true
+	}
+
+	return set
+}
@@ -0,0 +1,36 @@
+// Package endpoints
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-09
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
+)
+
+// upstreamError renders a failed call to the GIS backend. A backend that is known to be down
+// answers 503 and a call that ran out of time 504, so the viewer can tell both from a bug.
+// A network the backend does not know answers 404.
+func upstreamError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, gisapi.ErrNetworkNotFound):
+		return renders.JSONNotFound(c, err)
+	case errors.Is(err, gisapi.ErrCircuitOpen):
+		return renders.JSONServiceUnavailable(c, err)
+	case errors.Is(err, context.DeadlineExceeded), isTimeout(err):
+		return renders.JSONError(c, fiber.StatusGatewayTimeout, err)
+	default:
+		return renders.JSONInternalError(c, err)
+	}
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,242 @@
+// Package endpoints
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-20
+package endpoints
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/db"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/authz"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/requests"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/session"
+)
+
+const minPasswordLength = 8
+
+// UserInfo is the public representation of a user. It never includes the password hash.
+type UserInfo struct {
+	UUID      string    `json:"uuid"`
+	Name      string    `json:"name"`
+	Username  string    `json:"username"`
+	Role      db.Role   `json:"role"`
+	Disabled  bool      `json:"disabled"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type UserInfos []UserInfo
+
+func asUserInfo(u db.User) UserInfo {
+	return UserInfo{
+		UUID:      u.UUID,
+		Name:      u.Name,
+		Username:  u.Username,
+		Role:      u.Role,
+		Disabled:  u.Disabled,
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+	}
+}
+
+func UserList(c *fiber.Ctx) error {
+	if err := authz.RequireRole(c, db.RoleAdmin); err != nil {
+		return accessDenied(c, err)
+	}
+
+	users, err := db.ListUsers()
+	if err != nil {
+		return renders.JSONInternalError(c, err)
+	}
+
+	list := make(UserInfos, len(users))
+	for i, u := range users {
+		list[i] = asUserInfo(u)
+	}
+
+	return renders.JSONDataResponse(c, list)
+}
+
+func UserCurrent(c *fiber.Ctx) error {
+	user, ok := session.CurrentUser(c)
+	if !ok {
+		return renders.JSONUnauthorized(c, authz.ErrNotAuthenticated)
+	}
+
+	return renders.JSONDataResponse(c, asUserInfo(*user))
+}
+
+func UserCreate(c *fiber.Ctx) error {
+	if err := authz.RequireRole(c, db.RoleAdmin); err != nil {
+		return accessDenied(c, err)
+	}
+
+	var req requests.UserCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return renders.JSONBadRequest(c, ErrInvalidPayload)
+	}
+	req.Normalize()
+
+	if req.Name == "" {
+		return renders.JSONBadRequest(c, ErrNameRequired)
+	}
+	if req.Username == "" || req.Password == "" {
+		return renders.JSONBadRequest(c, ErrMissingCredentials)
+	}
+	if len(req.Password) < minPasswordLength {
+		return renders.JSONBadRequest(c, ErrPasswordTooShort)
+	}
+
+	role := db.RoleViewer
+	if req.Role != "" {
+		parsed, ok := db.ParseRole(req.Role)
+		if !ok {
+			return renders.JSONBadRequest(c, db.ErrInvalidRole)
+		}
+		role = parsed
+	}
+
+	user, err := db.CreateUser(req.Name, req.Username, req.Password, role)
+	if err != nil {
+		return userError(c, err)
+	}
+
+	return renders.JSONResponse(c, fiber.StatusCreated, fiber.Map{"data": asUserInfo(user)})
+}
+
+// UserUpdate changes the name of a user. Users may rename themselves; only admins change roles.
+func UserUpdate(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	var req requests.UserUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return renders.JSONBadRequest(c, ErrInvalidPayload)
+	}
+	req.Normalize()
+
+	if req.Name == "" && req.Role == "" {
+		return renders.JSONBadRequest(c, ErrNothingToUpdate)
+	}
+
+	if req.Role != "" || !isCurrentUser(c, uuid) {
+		if err := authz.RequireRole(c, db.RoleAdmin); err != nil {
+			return accessDenied(c, err)
+		}
+	}
+
+	var role db.Role
+	if req.Role != "" {
+		var ok bool
+		if role, ok = db.ParseRole(req.Role); !ok {
+			return renders.JSONBadRequest(c, db.ErrInvalidRole)
+		}
+	}
+
+	user, err := db.UpdateUser(uuid, req.Name, role)
+	if err != nil {
+		return userError(c, err)
+	}
+
+	return renders.JSONDataResponse(c, asUserInfo(user))
+}
+
+// UserChangePassword changes a password. Users changing their own must confirm the current one.
+func UserChangePassword(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	var req requests.UserPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return renders.JSONBadRequest(c, ErrInvalidPayload)
+	}
+	if len(req.NewPassword) < minPasswordLength {
+		return renders.JSONBadRequest(c, ErrPasswordTooShort)
+	}
+
+	if isCurrentUser(c, uuid) {
+		user, _ := session.CurrentUser(c)
+		if !user.CheckPassword(req.CurrentPassword) {
+			return renders.JSONForbidden(c, ErrCurrentPasswordInvalid)
+		}
+	} else if err := authz.RequireRole(c, db.RoleAdmin); err != nil {
+		return accessDenied(c, err)
+	}
+
+	if err := db.ChangeUserPassword(uuid, req.NewPassword); err != nil {
+		return userError(c, err)
+	}
+
+	return renders.JSONSuccessResponse(c)
+}
+
+func UserDisable(c *fiber.Ctx) error {
+	return setUserDisabled(c, true)
+}
+
+func UserEnable(c *fiber.Ctx) error {
+	return setUserDisabled(c, false)
+}
+
+func UserDelete(c *fiber.Ctx) error {
+	if err := authz.RequireRole(c, db.RoleAdmin); err != nil {
+		return accessDenied(c, err)
+	}
+
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	if err := db.DeleteUser(uuid); err != nil {
+		return userError(c, err)
+	}
+
+	return renders.JSONSuccessResponse(c)
+}
+
+func setUserDisabled(c *fiber.Ctx, disabled bool) error {
+	if err := authz.RequireRole(c, db.RoleAdmin); err != nil {
+		return accessDenied(c, err)
+	}
+
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return renders.JSONBadRequest(c, ErrUUIDRequired)
+	}
+
+	user, err := db.SetUserDisabled(uuid, disabled)
+	if err != nil {
+		return userError(c, err)
+	}
+
+	return renders.JSONDataResponse(c, asUserInfo(user))
+}
+
+func isCurrentUser(c *fiber.Ctx, uuid string) bool {
+	return session.CurrentUserUUID(c) == uuid
+}
+
+// userError maps user service errors to response codes.
+func userError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		return renders.JSONNotFound(c, err)
+	case errors.Is(err, db.ErrUsernameTaken):
+		return renders.JSONConflict(c, err)
+	case errors.Is(err, db.ErrInvalidRole):
+		return renders.JSONBadRequest(c, err)
+	case errors.Is(err, db.ErrLastAdmin):
+		return renders.JSONConflict(c, err)
+	default:
+		return renders.JSONInternalError(c, err)
+	}
+}
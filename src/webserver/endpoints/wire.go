@@ -0,0 +1,44 @@
+// Package endpoints
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-08
+package endpoints
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/crs"
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+	"github.com/teocci/go-hynix-3d-viewer/src/wire"
+)
+
+const HeaderNetworkCRS = "X-Network-CRS"
+
+// sendNodesBinary encodes the nodes with the wire package. Positions are float32, so they
+// are always sent relative to an origin: the ?origin query or the center of the nodes.
+func sendNodesBinary(c *fiber.Ctx, proj *projection, nodes gisapi.NodesData) error {
+	origin := proj.absoluteOrigin(nodes, nil)
+	if t := crs.NewTransformer(proj.src, proj.dst); t.Reprojects() {
+		nodes = t.Nodes(nodes)
+	}
+
+	return sendBinary(c, proj, wire.EncodeNodes(nodes, origin))
+}
+
+// sendLinksBinary encodes the links like sendNodesBinary.
+func sendLinksBinary(c *fiber.Ctx, proj *projection, links gisapi.LinksData) error {
+	origin := proj.absoluteOrigin(nil, links)
+	if t := crs.NewTransformer(proj.src, proj.dst); t.Reprojects() {
+		links = t.Links(links)
+	}
+
+	return sendBinary(c, proj, wire.EncodeLinks(links, origin))
+}
+
+func sendBinary(c *fiber.Ctx, proj *projection, body []byte) error {
+	c.Set(fiber.HeaderContentType, wire.MIMEType)
+	if target := proj.target(); target != nil {
+		c.Set(HeaderNetworkCRS, crs.Identifier(target))
+	}
+
+	return c.Send(body)
+}
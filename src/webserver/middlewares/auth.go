@@ -0,0 +1,62 @@
+// Package middlewares
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-17
+package middlewares
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/db"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/session"
+)
+
+// APIAuth rejects unauthenticated API requests with a JSON 401 response.
+func APIAuth() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if err := authenticate(c); err != nil {
+			return renders.JSONUnauthorized(c, err)
+		}
+
+		return c.Next()
+	}
+}
+
+// PageAuth sends page requests without a valid access token through the refresh endpoint,
+// which renews the session or ends on the login page.
+// Public pages, such as the login page itself, are served without a session.
+func PageAuth(public ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		for _, page := range public {
+			if c.Params("page") == page {
+				return c.Next()
+			}
+		}
+
+		if err := authenticate(c); err != nil {
+			return c.Redirect(session.RefreshPageURL(c), fiber.StatusSeeOther)
+		}
+
+		return c.Next()
+	}
+}
+
+// authenticate verifies the request token and stores the owning user in the context.
+func authenticate(c *fiber.Ctx) error {
+	userUUID, err := session.ParseToken(session.ExtractToken(c))
+	if err != nil {
+		return err
+	}
+
+	user, err := db.GetUserByUUID(userUUID)
+	if err != nil {
+		return session.ErrUserNotFound
+	}
+	if user.Disabled {
+		return session.ErrUserDisabled
+	}
+
+	session.SetUser(c, &user)
+
+	return nil
+}
@@ -0,0 +1,58 @@
+// Package middlewares
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-09
+package middlewares
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// disconnectCheckInterval is how often a running request checks that its client is still there.
+const disconnectCheckInterval = 250 * time.Millisecond
+
+// RequestContext gives every request a user context that handlers pass to upstream calls.
+// It is cancelled when the handler returns, when the server shuts down or when the client
+// closes its connection, so calls to the GIS backend never outlive the request that started
+// them. fasthttp does not report disconnects, so the connection is checked periodically.
+// The context does not derive from the fasthttp one, which is reused once the handler returns.
+func RequestContext() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx, cancel := context.WithCancel(context.Background())
+		shutdown := c.Context().Done()
+		conn := c.Context().Conn()
+
+		stopped := make(chan struct{})
+		go func() {
+			defer close(stopped)
+
+			ticker := time.NewTicker(disconnectCheckInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-shutdown:
+					cancel()
+					return
+				case <-ticker.C:
+					if connClosed(conn) {
+						cancel()
+						return
+					}
+				}
+			}
+		}()
+		defer func() {
+			cancel()
+			<-stopped
+		}()
+
+		c.SetUserContext(ctx)
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,51 @@
+// Package middlewares
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-09
+
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package middlewares
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRequestContextCancelsOnDisconnect(t *testing.T) {
+	cancelled := make(chan struct{})
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Get("/slow", RequestContext(), func(c *fiber.Ctx) error {
+		select {
+		case <-c.UserContext().Done():
+			close(cancelled)
+		case <-time.After(5 * time.Second):
+		}
+		return nil
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = app.Listener(ln) }()
+	defer app.Shutdown()
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conn.Write([]byte("GET /slow HTTP/1.1\r\nHost: test\r\n\r\n")); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	conn.Close()
+
+	select {
+	case <-cancelled:
+	case <-time.After(2 * time.Second):
+		t.Error("Expected the request context to be cancelled once the client left")
+	}
+}
@@ -0,0 +1,14 @@
+// Package middlewares
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-09
+
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package middlewares
+
+import "net"
+
+// connClosed cannot peek at sockets on this platform, so requests only end with their handler.
+func connClosed(net.Conn) bool {
+	return false
+}
@@ -0,0 +1,43 @@
+// Package middlewares
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-09
+
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package middlewares
+
+import (
+	"errors"
+	"net"
+	"syscall"
+)
+
+// connClosed reports whether the peer closed the connection. It peeks at the socket without
+// blocking or consuming any byte, so a pipelined request is left for the server to read.
+// Connections that do not expose their socket, such as TLS ones, are never reported closed.
+func connClosed(conn net.Conn) bool {
+	sc, ok := conn.(syscall.Conn)
+	if !ok {
+		return false
+	}
+	raw, err := sc.SyscallConn()
+	if err != nil {
+		return false
+	}
+
+	closed := false
+	err = raw.Read(func(fd uintptr) bool {
+		var buf [1]byte
+		n, _, err := syscall.Recvfrom(int(fd), buf[:], syscall.MSG_PEEK|syscall.MSG_DONTWAIT)
+		switch {
+		case err == nil:
+			closed = n == 0
+		case errors.Is(err, syscall.EAGAIN), errors.Is(err, syscall.EWOULDBLOCK), errors.Is(err, syscall.EINTR):
+		default:
+			closed = true
+		}
+		return true
+	})
+
+	return err == nil && closed
+}
@@ -6,6 +6,7 @@ package webserver
 import (
 	"github.com/gofiber/fiber/v2"
 
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/middlewares"
 	"github.com/teocci/go-hynix-3d-viewer/src/webserver/pages"
 )
 
@@ -14,5 +15,5 @@ func registerPages(app *fiber.App) {
 	app.Static("/", "./web")
 
 	page := app.Group("/page")
-	page.Get("/:page", pages.HandlePages)
+	page.Get("/:page", middlewares.PageAuth(string(pages.LoginPage)), pages.HandlePages)
 }
@@ -0,0 +1,25 @@
+// Package pages
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-19
+package pages
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/authz"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
+)
+
+// accessDenied renders an authorization error as a plain HTML error page.
+func accessDenied(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, authz.ErrNotAuthenticated):
+		return renders.HTMLUnauthorizedWithError(c, err)
+	case authz.IsForbidden(err):
+		return renders.HTMLForbiddenWithError(c, err)
+	default:
+		return renders.HTMLServerErrorWithError(c, err)
+	}
+}
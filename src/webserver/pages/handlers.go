@@ -6,6 +6,7 @@ package pages
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/session"
 )
 
 type PageType string
@@ -41,7 +42,11 @@ func HandlePages(c *fiber.Ctx) error {
 		return renders.StringNotFound(c)
 	}
 
-	page := renders.PageInfo{Name: pageName, Title: pageTitles[pageType]}
+	page := renders.PageInfo{
+		Name:     pageName,
+		Title:    pageTitles[pageType],
+		UserUUID: session.CurrentUserUUID(c),
+	}
 
 	switch pageType {
 	case LoginPage:
@@ -4,8 +4,13 @@
 package pages
 
 import (
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 
+	"github.com/teocci/go-hynix-3d-viewer/src/config"
+	"github.com/teocci/go-hynix-3d-viewer/src/scene"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/authz"
 	"github.com/teocci/go-hynix-3d-viewer/src/webserver/parsers"
 	"github.com/teocci/go-hynix-3d-viewer/src/webserver/renders"
 )
@@ -16,6 +21,16 @@ func handleNetworkViewer(c *fiber.Ctx, page renders.PageInfo) error {
 		return renders.HTMLBadRequestWithError(c, err)
 	}
 
+	// Several networks, or a network of another profile, are viewed as a merged scene.
+	networks := parsers.SplitAndTrim(network, ",")
+	if len(networks) > 1 || strings.Contains(network, ":") {
+		return handleSceneViewer(c, page, networks)
+	}
+
+	if err := authz.Network(c, network, authz.ActionRead); err != nil {
+		return accessDenied(c, err)
+	}
+
 	page.SetParam("viewer", "network")
 	page.SetParam("network", network)
 
@@ -23,6 +38,24 @@ func handleNetworkViewer(c *fiber.Ctx, page renders.PageInfo) error {
 	return renders.HTMLPage(c, page)
 }
 
+func handleSceneViewer(c *fiber.Ctx, page renders.PageInfo, networks []string) error {
+	for _, network := range networks {
+		ref, err := scene.ParseRef(network, config.Get().Profile)
+		if err != nil {
+			return renders.HTMLBadRequestWithError(c, err)
+		}
+		if err := authz.ProfileNetwork(c, ref.Profile, ref.UUID, authz.ActionRead); err != nil {
+			return accessDenied(c, err)
+		}
+	}
+
+	page.SetParam("viewer", "scene")
+	page.SetParam("networks", networks)
+
+	// Render the Viewer page
+	return renders.HTMLPage(c, page)
+}
+
 func handleProviderViewer(c *fiber.Ctx, page renders.PageInfo) error {
 	provider, err := parsers.QueryProvider(c)
 	if err != nil {
@@ -39,6 +72,15 @@ func handleProviderViewer(c *fiber.Ctx, page renders.PageInfo) error {
 		return renders.HTMLBadRequestWithError(c, ErrAtLeastOneUUIDRequired)
 	}
 
+	if err := authz.Provider(c, provider, authz.ActionRead); err != nil {
+		return accessDenied(c, err)
+	}
+	for _, uuid := range collections {
+		if err := authz.Collection(c, uuid, authz.ActionRead); err != nil {
+			return accessDenied(c, err)
+		}
+	}
+
 	page.SetParam("viewer", "collections")
 	page.SetParam("provider", provider)
 	page.SetParam("collections", collections)
@@ -9,4 +9,9 @@ var (
 	ErrCollectionsRequired = errors.New("collections UUIDs are required")
 	ErrProviderRequired    = errors.New("provider UUID is required")
 	ErrNetworkRequired     = errors.New("network UUID is required")
+	ErrInvalidIntList      = errors.New("expected a comma-separated list of integers")
+	ErrInvalidFloatList    = errors.New("expected a comma-separated list of numbers of the right length")
+	ErrInvalidPage         = errors.New("page must be a positive integer")
+	ErrInvalidPageSize     = errors.New("size must be a positive integer")
+	ErrInvalidSort         = errors.New("unknown sort field")
 )
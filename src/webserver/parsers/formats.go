@@ -0,0 +1,38 @@
+// Package parsers
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-28
+package parsers
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/geojson"
+	"github.com/teocci/go-hynix-3d-viewer/src/wire"
+)
+
+const (
+	FormatGeoJSON = "geojson"
+	FormatBinary  = "binary"
+)
+
+// WantsGeoJSON reports whether the client asked for GeoJSON through ?format=geojson
+// or an Accept header listing application/geo+json.
+func WantsGeoJSON(c *fiber.Ctx) bool {
+	if format, ok := queryString(c, "format"); ok {
+		return strings.EqualFold(format, FormatGeoJSON)
+	}
+
+	return strings.Contains(c.Get(fiber.HeaderAccept), geojson.MIMEType)
+}
+
+// WantsBinary reports whether the client asked for the columnar binary encoding through
+// ?format=binary or an Accept header listing its media type.
+func WantsBinary(c *fiber.Ctx) bool {
+	if format, ok := queryString(c, "format"); ok {
+		return strings.EqualFold(format, FormatBinary)
+	}
+
+	return strings.Contains(c.Get(fiber.HeaderAccept), wire.MIMEType)
+}
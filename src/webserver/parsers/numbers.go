@@ -0,0 +1,56 @@
+// Package parsers
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-02
+package parsers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// QueryIntList parses a comma-separated list of integers.
+// It returns false when the query is missing or empty.
+func QueryIntList(c *fiber.Ctx, key string) ([]int, bool, error) {
+	param, ok := queryString(c, key)
+	if !ok {
+		return nil, false, nil
+	}
+
+	parts := SplitAndTrim(param, ",")
+	values := make([]int, 0, len(parts))
+	for _, p := range parts {
+		v, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, true, ErrInvalidIntList
+		}
+		values = append(values, v)
+	}
+
+	return values, true, nil
+}
+
+// QueryFloatList parses a comma-separated list of exactly n numbers.
+// It returns false when the query is missing or empty.
+func QueryFloatList(c *fiber.Ctx, key string, n int) ([]float64, bool, error) {
+	param, ok := queryString(c, key)
+	if !ok {
+		return nil, false, nil
+	}
+
+	parts := SplitAndTrim(param, ",")
+	if len(parts) != n {
+		return nil, true, ErrInvalidFloatList
+	}
+
+	values := make([]float64, 0, n)
+	for _, p := range parts {
+		v, err := strconv.ParseFloat(p, 64)
+		if err != nil {
+			return nil, true, ErrInvalidFloatList
+		}
+		values = append(values, v)
+	}
+
+	return values, true, nil
+}
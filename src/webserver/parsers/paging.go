@@ -0,0 +1,74 @@
+// Package parsers
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-13
+package parsers
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Paging is a 1-based page of a list.
+type Paging struct {
+	Page int `json:"page"`
+	Size int `json:"size"`
+}
+
+// Slice returns the bounds of the page in a list of n items. Pages past the end are empty.
+func (p Paging) Slice(n int) (int, int) {
+	if p.Page < 1 || p.Size < 1 || p.Page-1 > n/p.Size {
+		return n, n
+	}
+
+	start := min((p.Page-1)*p.Size, n)
+
+	return start, start + min(p.Size, n-start)
+}
+
+// QueryPaging parses ?page and ?size. The size defaults to defaultSize and is capped at maxSize.
+func QueryPaging(c *fiber.Ctx, defaultSize, maxSize int) (Paging, error) {
+	p := Paging{Page: 1, Size: defaultSize}
+
+	if param, ok := queryString(c, "page"); ok {
+		page, err := strconv.Atoi(param)
+		if err != nil || page < 1 {
+			return p, ErrInvalidPage
+		}
+		p.Page = page
+	}
+
+	if param, ok := queryString(c, "size"); ok {
+		size, err := strconv.Atoi(param)
+		if err != nil || size < 1 {
+			return p, ErrInvalidPageSize
+		}
+		p.Size = min(size, maxSize)
+	}
+
+	// The first item of the page must be addressable.
+	if p.Page-1 > math.MaxInt/p.Size {
+		return p, ErrInvalidPage
+	}
+
+	return p, nil
+}
+
+// QuerySort parses ?sort as a field name, prefixed with "-" for descending order.
+// It returns the fallback when the query is missing.
+func QuerySort(c *fiber.Ctx, fallback string, fields ...string) (string, bool, error) {
+	param, ok := queryString(c, "sort")
+	if !ok {
+		return fallback, false, nil
+	}
+
+	field, desc := strings.CutPrefix(param, "-")
+	if !slices.Contains(fields, field) {
+		return "", false, ErrInvalidSort
+	}
+
+	return field, desc, nil
+}
@@ -0,0 +1,31 @@
+// Package parsers
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-13
+package parsers
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPagingSlice(t *testing.T) {
+	cases := []struct {
+		paging     Paging
+		n          int
+		start, end int
+	}{
+		{Paging{Page: 1, Size: 10}, 25, 0, 10},
+		{Paging{Page: 3, Size: 10}, 25, 20, 25},
+		{Paging{Page: 4, Size: 10}, 25, 25, 25},
+		{Paging{Page: 576460752303423489, Size: 16}, 25, 25, 25},
+		{Paging{Page: math.MaxInt, Size: math.MaxInt}, 25, 25, 25},
+		{Paging{Page: 0, Size: 10}, 25, 25, 25},
+	}
+
+	for _, tc := range cases {
+		start, end := tc.paging.Slice(tc.n)
+		if start != tc.start || end != tc.end {
+			t.Errorf("%+v of %d: expected [%d:%d], got [%d:%d]", tc.paging, tc.n, tc.start, tc.end, start, end)
+		}
+	}
+}
@@ -39,6 +39,21 @@ func JSONNotFound(c *fiber.Ctx, err error) error {
 	return JSONError(c, fiber.StatusNotFound, err)
 }
 
+func JSONConflict(c *fiber.Ctx, err error) error {
+	return JSONError(c, fiber.StatusConflict, err)
+}
+
+func JSONServiceUnavailable(c *fiber.Ctx, err error) error {
+	return JSONError(c, fiber.StatusServiceUnavailable, err)
+}
+
+func JSONUnprocessableWithIssues(c *fiber.Ctx, err error, issues any) error {
+	return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+		"error":  err.Error(),
+		"issues": issues,
+	})
+}
+
 func JSONNotFoundWithPath(c *fiber.Ctx, msg string, path string) error {
 	return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 		"error": msg,
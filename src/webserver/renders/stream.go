@@ -4,18 +4,27 @@
 package renders
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
 	"io"
 
 	"github.com/gofiber/fiber/v2"
 )
 
+const streamBufferSize = 32 << 10
+
 // StreamResponse streams large JSON responses efficiently
 // This prevents loading the entire response into memory at once
 // Using generics for type safety
 func StreamResponse[T any](c *fiber.Ctx, payload T) error {
+	return StreamResponseWithType(c, fiber.MIMEApplicationJSON, payload)
+}
+
+// StreamResponseWithType streams a JSON payload using the given content type
+func StreamResponseWithType[T any](c *fiber.Ctx, contentType string, payload T) error {
 	// Set content type
-	c.Set("Content-Type", "application/json")
+	c.Set(fiber.HeaderContentType, contentType)
 	// Create a pipe for streaming
 	pr, pw := io.Pipe()
 
@@ -35,3 +44,68 @@ func StreamResponse[T any](c *fiber.Ctx, payload T) error {
 	// Send the stream to the client
 	return c.SendStream(pr)
 }
+
+// StreamArray streams a JSON object made of the fields of head and an array under key.
+// The elements are written by produce as they come, so the array is never held in memory.
+// An error from produce truncates the response, since the status line was already sent.
+func StreamArray(c *fiber.Ctx, contentType string, head R, key string, produce func(emit func(any) error) error) error {
+	c.Set(fiber.HeaderContentType, contentType)
+	pr, pw := io.Pipe()
+
+	go func() {
+		w := bufio.NewWriterSize(pw, streamBufferSize)
+		if err := writeArray(w, head, key, produce); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		if err := w.Flush(); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		pw.Close()
+	}()
+
+	return c.SendStream(pr)
+}
+
+func writeArray(w io.Writer, head R, key string, produce func(emit func(any) error) error) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+
+	var prefix bytes.Buffer
+	headEncoder := json.NewEncoder(&prefix)
+	headEncoder.SetEscapeHTML(false)
+	if err := headEncoder.Encode(head); err != nil {
+		return err
+	}
+
+	// Reopen the encoded head object to append the array as its last field.
+	open := bytes.TrimSuffix(bytes.TrimSuffix(prefix.Bytes(), []byte("\n")), []byte("}"))
+	if len(head) > 0 {
+		open = append(open, ',')
+	}
+	name, _ := json.Marshal(key)
+	open = append(append(open, name...), ":["...)
+	if _, err := w.Write(open); err != nil {
+		return err
+	}
+
+	first := true
+	emit := func(v any) error {
+		if !first {
+			if _, err := w.Write([]byte{','}); err != nil {
+				return err
+			}
+		}
+		first = false
+
+		return encoder.Encode(v)
+	}
+	if err := produce(emit); err != nil {
+		return err
+	}
+
+	_, err := w.Write([]byte("]}\n"))
+
+	return err
+}
@@ -0,0 +1,45 @@
+// Package renders
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-11
+package renders
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestWriteArray(t *testing.T) {
+	tests := []struct {
+		head  R
+		items []any
+		want  string
+	}{
+		{R{"uuid": "n1", "origin": []int{1, 2}}, []any{1, R{"a": "<b>"}}, `{"origin":[1,2],"uuid":"n1","data":[1,{"a":"<b>"}]}`},
+		{R{"nested": R{"x": 1}}, nil, `{"nested":{"x":1},"data":[]}`},
+		{R{}, []any{"x"}, `{"data":["x"]}`},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		err := writeArray(&out, tt.head, "data", func(emit func(any) error) error {
+			for _, item := range tt.items {
+				if err := emit(item); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, out.Bytes()); err != nil {
+			t.Fatalf("Invalid JSON %q: %v", out.String(), err)
+		}
+		if compact.String() != tt.want {
+			t.Errorf("Expected %s, got %s", tt.want, compact.String())
+		}
+	}
+}
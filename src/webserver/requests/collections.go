@@ -0,0 +1,21 @@
+// Package requests
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-24
+package requests
+
+import "github.com/teocci/go-hynix-3d-viewer/src/gisdata"
+
+// CollectionCreateRequest is the payload used to create a collection under a provider.
+type CollectionCreateRequest struct {
+	Name     string           `json:"name"`
+	Provider string           `json:"provider"`
+	GIS      *gisdata.GISData `json:"gis"`
+}
+
+// CollectionUpdateRequest is the payload used to update a collection. Omitted fields are left unchanged.
+// Message describes the change in the collection history.
+type CollectionUpdateRequest struct {
+	Name    *string          `json:"name"`
+	GIS     *gisdata.GISData `json:"gis"`
+	Message string           `json:"message"`
+}
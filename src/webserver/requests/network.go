@@ -0,0 +1,12 @@
+// Package requests
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-14
+package requests
+
+import "github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+
+// NetworkAttributesRequest is the payload used to look up the attributes of a selection.
+type NetworkAttributesRequest struct {
+	Nodes gisapi.FeatureRefs `json:"nodes"`
+	Links gisapi.FeatureRefs `json:"links"`
+}
@@ -0,0 +1,33 @@
+// Package requests
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-21
+package requests
+
+// ProviderCreateRequest is the payload used to register a provider.
+type ProviderCreateRequest struct {
+	Name       string `json:"name"`
+	Host       string `json:"host"`
+	Port       int    `json:"port"`
+	DBUser     string `json:"dbUser"`
+	DBPassword string `json:"dbPassword"`
+}
+
+// ProviderUpdateRequest is the payload used to update a provider. Omitted fields are left unchanged.
+type ProviderUpdateRequest struct {
+	Name       *string `json:"name"`
+	Host       *string `json:"host"`
+	Port       *int    `json:"port"`
+	DBUser     *string `json:"dbUser"`
+	DBPassword *string `json:"dbPassword"`
+}
+
+// ProviderLinkRequest is the payload used to grant a user access to a provider.
+type ProviderLinkRequest struct {
+	UserUUID string `json:"userUuid"`
+	Role     string `json:"role"`
+}
+
+// ProviderNetworksRequest is the payload used to register networks under a provider.
+type ProviderNetworksRequest struct {
+	Networks []string `json:"networks"`
+}
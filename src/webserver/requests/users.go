@@ -0,0 +1,40 @@
+// Package requests
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-20
+package requests
+
+import "strings"
+
+// UserCreateRequest is the payload used to register a new user.
+type UserCreateRequest struct {
+	Name     string `json:"name"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Role     string `json:"role"`
+}
+
+// UserUpdateRequest is the payload used to update a user. Empty fields are left unchanged.
+type UserUpdateRequest struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// UserPasswordRequest is the payload used to change a password.
+// CurrentPassword is required when users change their own password.
+type UserPasswordRequest struct {
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword"`
+}
+
+// Normalize trims the identifying fields.
+func (r *UserCreateRequest) Normalize() {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Username = strings.TrimSpace(r.Username)
+	r.Role = strings.TrimSpace(r.Role)
+}
+
+// Normalize trims the identifying fields.
+func (r *UserUpdateRequest) Normalize() {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Role = strings.TrimSpace(r.Role)
+}
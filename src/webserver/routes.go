@@ -7,16 +7,61 @@ import (
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/teocci/go-hynix-3d-viewer/src/webserver/endpoints"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/middlewares"
 )
 
 func registerAPIEndpoints(app *fiber.App) fiber.Router {
 	// Create an API route group.
-	api := app.Group("/api/v1")
+	api := app.Group("/api/v1", middlewares.APIAuth(), middlewares.RequestContext())
 	api.Get("/collections/list", endpoints.CollectionList)
 	api.Get("/collections", endpoints.Collections)
-	api.Get("/collection", endpoints.Collection)
+	api.Post("/collections", endpoints.CollectionCreate)
+	api.Post("/collections/validate", endpoints.CollectionValidate)
+	api.Post("/collections/geojson", endpoints.CollectionImportGeoJSON)
+	api.Get("/collections/:uuid", endpoints.Collection)
+	api.Put("/collections/:uuid", endpoints.CollectionUpdate)
+	api.Delete("/collections/:uuid", endpoints.CollectionDelete)
+	api.Get("/collections/:uuid/revisions", endpoints.CollectionRevisions)
+	api.Get("/collections/:uuid/revisions/:revision", endpoints.CollectionRevision)
+	api.Post("/collections/:uuid/revisions/:revision/rollback", endpoints.CollectionRollback)
+	api.Get("/collections/:uuid/diff", endpoints.CollectionDiff)
 
+	api.Get("/networks", endpoints.NetworkList)
+	api.Get("/scene", endpoints.NetworkScene)
+	api.Get("/network/:uuid/export.glb", endpoints.NetworkExportGLB)
+	api.Get("/network/:uuid/topology/:analysis?", endpoints.NetworkTopology)
+	api.Get("/network/:uuid/trace", endpoints.NetworkTrace)
+	api.Get("/network/:uuid/query", endpoints.NetworkQuery)
+	api.Get("/network/:uuid/tiles", endpoints.NetworkTileset)
+	api.Get("/network/:uuid/tiles/:level/:x/:y/:z", endpoints.NetworkTile)
+	api.Get("/network/:uuid/nodes/:id", endpoints.NetworkNodeAttributes)
+	api.Get("/network/:uuid/links/:id", endpoints.NetworkLinkAttributes)
+	api.Post("/network/:uuid/attributes", endpoints.NetworkAttributes)
+	api.Get("/network/:uuid/legend", endpoints.NetworkLegend)
 	api.Get("/network/:uuid/:kind", endpoints.NetworkHandler)
 
+	api.Get("/cache", endpoints.CacheStats)
+	api.Delete("/cache", endpoints.CacheInvalidate)
+	api.Delete("/cache/networks/:uuid", endpoints.CacheInvalidateNetwork)
+
+	api.Get("/users", endpoints.UserList)
+	api.Post("/users", endpoints.UserCreate)
+	api.Get("/users/me", endpoints.UserCurrent)
+	api.Patch("/users/:uuid", endpoints.UserUpdate)
+	api.Put("/users/:uuid/password", endpoints.UserChangePassword)
+	api.Post("/users/:uuid/disable", endpoints.UserDisable)
+	api.Post("/users/:uuid/enable", endpoints.UserEnable)
+	api.Delete("/users/:uuid", endpoints.UserDelete)
+
+	api.Get("/providers", endpoints.ProviderList)
+	api.Post("/providers", endpoints.ProviderCreate)
+	api.Get("/providers/:uuid", endpoints.ProviderDetails)
+	api.Patch("/providers/:uuid", endpoints.ProviderUpdate)
+	api.Delete("/providers/:uuid", endpoints.ProviderDelete)
+	api.Post("/providers/:uuid/users", endpoints.ProviderLink)
+	api.Get("/providers/:uuid/networks", endpoints.ProviderNetworks)
+	api.Post("/providers/:uuid/networks", endpoints.ProviderNetworkRegister)
+	api.Delete("/providers/:uuid/networks/:network", endpoints.ProviderNetworkUnregister)
+
 	return api
 }
@@ -0,0 +1,21 @@
+// Package session
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-17
+package session
+
+import "errors"
+
+var (
+	ErrInvalidToken         = errors.New("invalid token")
+	ErrTokenExpired         = errors.New("token expired")
+	ErrTokenMissing         = errors.New("token missing")
+	ErrTokenRevoked         = errors.New("token revoked")
+	ErrUserNotFound         = errors.New("user not found")
+	ErrUserDisabled         = errors.New("user is disabled")
+	ErrDuplicateKID         = errors.New("duplicate signing key id")
+	ErrUnknownKID           = errors.New("unknown signing key id")
+	ErrKeyCannotSign        = errors.New("signing key has no private key")
+	ErrMissingSecret        = errors.New("HS256 key requires a secret")
+	ErrMissingKeyFile       = errors.New("signing key requires a private or public key file")
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+)
@@ -0,0 +1,99 @@
+// Package session
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-17
+package session
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	CookieName   = "token"
+	bearerPrefix = "Bearer "
+
+	headerKID = "kid"
+
+	claimUUID = "uuid"
+	claimExp  = "exp"
+	claimIat  = "iat"
+)
+
+// GenerateToken creates a short-lived access token for the user, signed with the active key.
+// It returns the token and its expiration time.
+func GenerateToken(userUUID string) (string, time.Time, error) {
+	kr := currentKeyring()
+
+	now := time.Now()
+	expiresAt := now.Add(kr.accessTTL)
+	claims := jwt.MapClaims{
+		claimUUID: userUUID,
+		claimIat:  now.Unix(),
+		claimExp:  expiresAt.Unix(),
+	}
+
+	token := jwt.NewWithClaims(kr.active.method, claims)
+	token.Header[headerKID] = kr.active.kid
+
+	signed, err := token.SignedString(kr.active.signKey)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return signed, expiresAt, nil
+}
+
+// ParseToken verifies the token signature and expiration and returns the user UUID it carries.
+// The key is selected through the kid header, so tokens signed by any configured key remain valid.
+func ParseToken(raw string) (string, error) {
+	if raw == "" {
+		return "", ErrTokenMissing
+	}
+
+	kr := currentKeyring()
+	token, err := jwt.Parse(raw, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header[headerKID].(string)
+		key, ok := kr.keys[kid]
+		if !ok {
+			return nil, ErrUnknownKID
+		}
+		if t.Method.Alg() != key.method.Alg() {
+			return nil, ErrInvalidToken
+		}
+
+		return key.verifyKey, nil
+	}, jwt.WithExpirationRequired())
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return "", ErrTokenExpired
+		}
+		return "", ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return "", ErrInvalidToken
+	}
+
+	userUUID, ok := claims[claimUUID].(string)
+	if !ok || userUUID == "" {
+		return "", ErrInvalidToken
+	}
+
+	return userUUID, nil
+}
+
+// ExtractToken reads the raw token from the Authorization header or the token cookie.
+// The header takes precedence so API clients can override a stale browser cookie.
+func ExtractToken(c *fiber.Ctx) string {
+	header := c.Get(fiber.HeaderAuthorization)
+	if strings.HasPrefix(header, bearerPrefix) {
+		return strings.TrimSpace(strings.TrimPrefix(header, bearerPrefix))
+	}
+
+	return c.Cookies(CookieName)
+}
@@ -0,0 +1,87 @@
+// Package session
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-18
+package session
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/config"
+)
+
+const testUserUUID = "7f1c2a52-8a0e-4a36-9d1f-6c1f0b6f9e11"
+
+func initKeys(t *testing.T, setup config.AuthSetup) {
+	t.Helper()
+	if err := Init(setup); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+}
+
+func TestTokenRoundTrip(t *testing.T) {
+	initKeys(t, config.AuthSetup{Secret: "round-trip"})
+
+	token, expiresAt, err := GenerateToken(testUserUUID)
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+	if !expiresAt.After(time.Now()) {
+		t.Errorf("Expected expiration in the future, got %v", expiresAt)
+	}
+
+	userUUID, err := ParseToken(token)
+	if err != nil {
+		t.Fatalf("ParseToken failed: %v", err)
+	}
+	if userUUID != testUserUUID {
+		t.Errorf("Expected uuid '%s', got '%s'", testUserUUID, userUUID)
+	}
+}
+
+func TestTokenSurvivesKeyRotation(t *testing.T) {
+	keyA := config.AuthKey{KID: "a", Algorithm: config.AuthAlgorithmHS256, Secret: "secret-a"}
+	keyB := config.AuthKey{KID: "b", Algorithm: config.AuthAlgorithmHS256, Secret: "secret-b"}
+
+	initKeys(t, config.AuthSetup{ActiveKID: "a", Keys: []config.AuthKey{keyA}})
+	token, _, err := GenerateToken(testUserUUID)
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+
+	initKeys(t, config.AuthSetup{ActiveKID: "b", Keys: []config.AuthKey{keyA, keyB}})
+	if _, err := ParseToken(token); err != nil {
+		t.Errorf("Expected token signed by retired key to stay valid, got %v", err)
+	}
+
+	initKeys(t, config.AuthSetup{ActiveKID: "b", Keys: []config.AuthKey{keyB}})
+	if _, err := ParseToken(token); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken after key removal, got %v", err)
+	}
+}
+
+func TestExpiredToken(t *testing.T) {
+	initKeys(t, config.AuthSetup{Secret: "expired"})
+
+	// A non-positive TTL falls back to the default, so expire tokens by hand.
+	ring.accessTTL = -time.Minute
+	token, _, err := GenerateToken(testUserUUID)
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+
+	if _, err := ParseToken(token); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("Expected ErrTokenExpired, got %v", err)
+	}
+}
+
+func TestInitRejectsUnknownActiveKey(t *testing.T) {
+	setup := config.AuthSetup{
+		ActiveKID: "missing",
+		Keys:      []config.AuthKey{{KID: "a", Algorithm: config.AuthAlgorithmHS256, Secret: "secret-a"}},
+	}
+	if err := Init(setup); !errors.Is(err, ErrUnknownKID) {
+		t.Errorf("Expected ErrUnknownKID, got %v", err)
+	}
+}
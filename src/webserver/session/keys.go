@@ -0,0 +1,217 @@
+// Package session
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-18
+package session
+
+import (
+	"crypto"
+	"crypto/rand"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/config"
+)
+
+const defaultKID = "default"
+
+// signingKey pairs a signing method with the keys used to sign and verify tokens.
+// A key without signKey can only verify tokens issued before a rotation.
+type signingKey struct {
+	kid       string
+	method    jwt.SigningMethod
+	signKey   any
+	verifyKey any
+}
+
+type keyring struct {
+	active     *signingKey
+	keys       map[string]*signingKey
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+var (
+	ring      *keyring
+	ringMutex sync.RWMutex
+)
+
+// Init loads the signing keys and token lifetimes from the auth setup.
+func Init(setup config.AuthSetup) error {
+	kr, err := newKeyring(setup)
+	if err != nil {
+		return err
+	}
+
+	ringMutex.Lock()
+	defer ringMutex.Unlock()
+
+	ring = kr
+
+	return nil
+}
+
+func currentKeyring() *keyring {
+	ringMutex.RLock()
+	kr := ring
+	ringMutex.RUnlock()
+	if kr != nil {
+		return kr
+	}
+
+	// Tokens must never be signed with a well-known secret, so fall back to an ephemeral one.
+	if err := Init(config.AuthSetup{}); err != nil {
+		log.Fatalf("Failed to initialize session keys: %v", err)
+	}
+
+	ringMutex.RLock()
+	defer ringMutex.RUnlock()
+
+	return ring
+}
+
+func newKeyring(setup config.AuthSetup) (*keyring, error) {
+	kr := &keyring{
+		keys:       make(map[string]*signingKey),
+		accessTTL:  setup.AccessTTL,
+		refreshTTL: setup.RefreshTTL,
+	}
+	if kr.accessTTL <= 0 {
+		kr.accessTTL = 15 * time.Minute
+	}
+	if kr.refreshTTL <= 0 {
+		kr.refreshTTL = 7 * 24 * time.Hour
+	}
+
+	keys := setup.Keys
+	if len(keys) == 0 {
+		secret := setup.Secret
+		if secret == "" {
+			secret = randomSecret()
+			log.Println("No JWT secret configured, using an ephemeral key. Sessions will not survive a restart.")
+		}
+		keys = []config.AuthKey{{KID: defaultKID, Algorithm: config.AuthAlgorithmHS256, Secret: secret}}
+	}
+
+	for _, k := range keys {
+		key, err := loadKey(k)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := kr.keys[key.kid]; exists {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateKID, key.kid)
+		}
+		kr.keys[key.kid] = key
+	}
+
+	activeKID := setup.ActiveKID
+	if activeKID == "" {
+		activeKID = keys[0].KID
+		if activeKID == "" {
+			activeKID = defaultKID
+		}
+	}
+
+	active, ok := kr.keys[activeKID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKID, activeKID)
+	}
+	if active.signKey == nil {
+		return nil, fmt.Errorf("%w: %s", ErrKeyCannotSign, activeKID)
+	}
+	kr.active = active
+
+	return kr, nil
+}
+
+func loadKey(k config.AuthKey) (*signingKey, error) {
+	kid := k.KID
+	if kid == "" {
+		kid = defaultKID
+	}
+
+	key := &signingKey{kid: kid}
+
+	switch k.Algorithm {
+	case config.AuthAlgorithmHS256, "":
+		if k.Secret == "" {
+			return nil, fmt.Errorf("%w: %s", ErrMissingSecret, kid)
+		}
+		key.method = jwt.SigningMethodHS256
+		key.signKey = []byte(k.Secret)
+		key.verifyKey = key.signKey
+	case config.AuthAlgorithmRS256:
+		key.method = jwt.SigningMethodRS256
+		if k.PrivateKey != "" {
+			raw, err := os.ReadFile(k.PrivateKey)
+			if err != nil {
+				return nil, err
+			}
+			private, err := jwt.ParseRSAPrivateKeyFromPEM(raw)
+			if err != nil {
+				return nil, fmt.Errorf("key %s: %w", kid, err)
+			}
+			key.signKey = private
+			key.verifyKey = &private.PublicKey
+		}
+		if k.PublicKey != "" {
+			raw, err := os.ReadFile(k.PublicKey)
+			if err != nil {
+				return nil, err
+			}
+			public, err := jwt.ParseRSAPublicKeyFromPEM(raw)
+			if err != nil {
+				return nil, fmt.Errorf("key %s: %w", kid, err)
+			}
+			key.verifyKey = public
+		}
+	case config.AuthAlgorithmEdDSA:
+		key.method = jwt.SigningMethodEdDSA
+		if k.PrivateKey != "" {
+			raw, err := os.ReadFile(k.PrivateKey)
+			if err != nil {
+				return nil, err
+			}
+			private, err := jwt.ParseEdPrivateKeyFromPEM(raw)
+			if err != nil {
+				return nil, fmt.Errorf("key %s: %w", kid, err)
+			}
+			key.signKey = private
+			if signer, ok := private.(crypto.Signer); ok {
+				key.verifyKey = signer.Public()
+			}
+		}
+		if k.PublicKey != "" {
+			raw, err := os.ReadFile(k.PublicKey)
+			if err != nil {
+				return nil, err
+			}
+			public, err := jwt.ParseEdPublicKeyFromPEM(raw)
+			if err != nil {
+				return nil, fmt.Errorf("key %s: %w", kid, err)
+			}
+			key.verifyKey = public
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, k.Algorithm)
+	}
+
+	if key.verifyKey == nil {
+		return nil, fmt.Errorf("%w: %s", ErrMissingKeyFile, kid)
+	}
+
+	return key, nil
+}
+
+func randomSecret() string {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		log.Fatalf("Failed to generate JWT secret: %v", err)
+	}
+
+	return fmt.Sprintf("%x", buf)
+}
@@ -0,0 +1,36 @@
+// Package session
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-17
+package session
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/db"
+)
+
+const localsUserKey = "user"
+
+// SetUser stores the authenticated user in the request context.
+func SetUser(c *fiber.Ctx, user *db.User) {
+	c.Locals(localsUserKey, user)
+}
+
+// CurrentUser returns the authenticated user stored by the auth middleware.
+func CurrentUser(c *fiber.Ctx) (*db.User, bool) {
+	user, ok := c.Locals(localsUserKey).(*db.User)
+	if !ok || user == nil {
+		return nil, false
+	}
+
+	return user, true
+}
+
+// CurrentUserUUID returns the UUID of the authenticated user or an empty string.
+func CurrentUserUUID(c *fiber.Ctx) string {
+	if user, ok := CurrentUser(c); ok {
+		return user.UUID
+	}
+
+	return ""
+}
@@ -0,0 +1,120 @@
+// Package session
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-18
+package session
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/db"
+)
+
+const (
+	RefreshCookieName = "refresh_token"
+	RefreshCookiePath = "/auth"
+
+	// RefreshPagePath refreshes the session of a page request, see RefreshPageURL.
+	RefreshPagePath = RefreshCookiePath + "/refresh"
+	LoginPagePath   = "/page/login"
+
+	refreshTokenSize = 32
+)
+
+// GenerateRefreshToken issues an opaque refresh token for the user and stores its hash.
+// It returns the raw token, which is never persisted, and its expiration time.
+func GenerateRefreshToken(userUUID string) (string, time.Time, error) {
+	kr := currentKeyring()
+
+	buf := make([]byte, refreshTokenSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", time.Time{}, err
+	}
+	raw := base64.RawURLEncoding.EncodeToString(buf)
+
+	expiresAt := time.Now().Add(kr.refreshTTL)
+	if _, err := db.CreateRefreshToken(userUUID, hashToken(raw), expiresAt); err != nil {
+		return "", time.Time{}, err
+	}
+
+	return raw, expiresAt, nil
+}
+
+// RotateRefreshToken validates a refresh token, revokes it and returns the owning user UUID.
+// The caller is expected to issue a new token pair for the returned user.
+func RotateRefreshToken(raw string) (string, error) {
+	if raw == "" {
+		return "", ErrTokenMissing
+	}
+
+	hash := hashToken(raw)
+	token, err := db.GetRefreshToken(hash)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+
+	if token.RevokedAt != nil {
+		// A revoked token being replayed means it may have leaked, so end every session of the user.
+		_ = db.RevokeUserRefreshTokens(token.UserUUID)
+		return "", ErrTokenRevoked
+	}
+	if !token.IsActive() {
+		return "", ErrTokenExpired
+	}
+
+	// Only the call that actually revokes the token may rotate it; another request
+	// presenting the same token at the same time lost the race.
+	revoked, err := db.RevokeRefreshToken(hash)
+	if err != nil {
+		return "", err
+	}
+	if revoked == 0 {
+		return "", ErrInvalidToken
+	}
+
+	return token.UserUUID, nil
+}
+
+// RevokeRefreshToken revokes the given refresh token, if any.
+func RevokeRefreshToken(raw string) error {
+	if raw == "" {
+		return nil
+	}
+
+	_, err := db.RevokeRefreshToken(hashToken(raw))
+
+	return err
+}
+
+// ExtractRefreshToken reads the raw refresh token from its cookie.
+func ExtractRefreshToken(c *fiber.Ctx) string {
+	return c.Cookies(RefreshCookieName)
+}
+
+// RefreshPageURL returns where to send a page request whose access token expired: the
+// refresh endpoint, which can read the refresh cookie and then sends the browser back.
+func RefreshPageURL(c *fiber.Ctx) string {
+	return RefreshPagePath + "?next=" + url.QueryEscape(c.OriginalURL())
+}
+
+// LocalRedirect returns next when it is a path of this server, or "/" otherwise,
+// so a crafted link cannot send the browser to another site.
+func LocalRedirect(next string) string {
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") || strings.HasPrefix(next, "/\\") {
+		return "/"
+	}
+
+	return next
+}
+
+func hashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,22 @@
+// Package session
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-3월-18
+package session
+
+import "testing"
+
+func TestLocalRedirect(t *testing.T) {
+	cases := map[string]string{
+		"/page/viewer?network=n1": "/page/viewer?network=n1",
+		"":                        "/",
+		"https://example.com/":    "/",
+		"//example.com/":          "/",
+		"/\\example.com/":         "/",
+	}
+
+	for next, want := range cases {
+		if got := LocalRedirect(next); got != want {
+			t.Errorf("LocalRedirect(%q) = %q, want %q", next, got, want)
+		}
+	}
+}
@@ -11,9 +11,14 @@ import (
 	"github.com/gofiber/template/html/v2"
 
 	"github.com/teocci/go-hynix-3d-viewer/src/config"
+	"github.com/teocci/go-hynix-3d-viewer/src/webserver/session"
 )
 
 func Start(cfg *config.ServerSetup) {
+	if err := session.Init(cfg.Auth); err != nil {
+		log.Fatalf("Failed to initialize session keys: %v", err)
+	}
+
 	engine := html.New("./src/views", ".tpl")
 	engine.Reload(true)
 
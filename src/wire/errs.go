@@ -0,0 +1,11 @@
+// Package wire
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-08
+package wire
+
+import "errors"
+
+var (
+	ErrInvalidMessage = errors.New("not a binary network message")
+	ErrKindMismatch   = errors.New("binary network message holds another kind")
+)
@@ -0,0 +1,307 @@
+// Package wire
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-08
+//
+// Package wire encodes network geometry as columnar little-endian buffers that the viewer
+// can hand to Three.js BufferGeometry without parsing JSON.
+//
+// Every message starts with a 48-byte header:
+//
+//	offset  type        field
+//	0       [4]byte     magic "HNB1"
+//	4       uint32      version (1)
+//	8       uint32      kind: 1 nodes, 2 links
+//	12      uint32      count: number of elements
+//	16      uint32      vertices: number of positions
+//	20      uint32      reserved (0)
+//	24      float64[3]  origin added back to every position
+//
+// Nodes then carry, column after column:
+//
+//	int32[count]          ids
+//	int32[count]          types
+//	float32[vertices*3]   positions (vertices == count)
+//	strings               guids
+//
+// Links carry:
+//
+//	int32[count]          ids
+//	int32[count]          types
+//	int32[count]          sequence numbers
+//	int32[count]          start node ids
+//	int32[count]          end node ids
+//	uint32[count+1]       offsets of the first vertex of each link; the last one is vertices
+//	float32[vertices*3]   positions
+//	strings               guids
+//
+// A strings column is uint32[count+1] byte offsets followed by the UTF-8 bytes, padded with
+// zeros to a multiple of four. Every column starts on a 4-byte boundary, so a Float32Array
+// or Int32Array view can be created over the response buffer directly.
+package wire
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+)
+
+// MIMEType is the media type clients list in Accept to receive this encoding.
+const MIMEType = "application/vnd.hynix.network"
+
+const (
+	Version    = 1
+	HeaderSize = 48
+
+	KindNodes uint32 = 1
+	KindLinks uint32 = 2
+)
+
+var magic = [4]byte{'H', 'N', 'B', '1'}
+
+type header struct {
+	Magic    [4]byte
+	Version  uint32
+	Kind     uint32
+	Count    uint32
+	Vertices uint32
+	Reserved uint32
+	Origin   [3]float64
+}
+
+// EncodeNodes writes the nodes relative to origin; a nil origin means the zero origin.
+func EncodeNodes(nodes gisapi.NodesData, origin []float64) []byte {
+	ids := make([]int32, len(nodes))
+	types := make([]int32, len(nodes))
+	positions := make([]float32, 0, len(nodes)*3)
+	guids := make([]string, len(nodes))
+
+	o := point(origin)
+	for i, n := range nodes {
+		ids[i] = int32(n.ID)
+		types[i] = int32(n.Type)
+		positions = appendPosition(positions, n.Geometry, o)
+		guids[i] = n.Guid
+	}
+
+	var buf bytes.Buffer
+	writeHeader(&buf, header{Kind: KindNodes, Count: uint32(len(nodes)), Vertices: uint32(len(nodes)), Origin: o})
+	write(&buf, ids, types, positions)
+	writeStrings(&buf, guids)
+
+	return buf.Bytes()
+}
+
+// EncodeLinks writes the links relative to origin; a nil origin means the zero origin.
+func EncodeLinks(links gisapi.LinksData, origin []float64) []byte {
+	count := len(links)
+	ids := make([]int32, count)
+	types := make([]int32, count)
+	sequences := make([]int32, count)
+	starts := make([]int32, count)
+	ends := make([]int32, count)
+	offsets := make([]uint32, count+1)
+	positions := make([]float32, 0)
+	guids := make([]string, count)
+
+	o := point(origin)
+	for i, l := range links {
+		ids[i] = int32(l.ID)
+		types[i] = int32(l.Type)
+		sequences[i] = int32(l.SequenceNo)
+		starts[i] = int32(l.StartNodeId)
+		ends[i] = int32(l.EndNodeId)
+		guids[i] = l.Guid
+		for _, p := range l.Geometry {
+			positions = appendPosition(positions, p, o)
+		}
+		offsets[i+1] = uint32(len(positions) / 3)
+	}
+
+	var buf bytes.Buffer
+	writeHeader(&buf, header{Kind: KindLinks, Count: uint32(count), Vertices: offsets[count], Origin: o})
+	write(&buf, ids, types, sequences, starts, ends, offsets, positions)
+	writeStrings(&buf, guids)
+
+	return buf.Bytes()
+}
+
+func point(origin []float64) [3]float64 {
+	var o [3]float64
+	copy(o[:], origin)
+
+	return o
+}
+
+func appendPosition(positions []float32, p []float64, origin [3]float64) []float32 {
+	for i := 0; i < 3; i++ {
+		v := 0.0
+		if i < len(p) {
+			v = p[i] - origin[i]
+		}
+		positions = append(positions, float32(v))
+	}
+
+	return positions
+}
+
+func writeHeader(buf *bytes.Buffer, h header) {
+	h.Magic = magic
+	h.Version = Version
+	write(buf, h)
+}
+
+// write appends fixed-size values; writing to a bytes.Buffer cannot fail.
+func write(buf *bytes.Buffer, columns ...any) {
+	for _, c := range columns {
+		_ = binary.Write(buf, binary.LittleEndian, c)
+	}
+}
+
+func writeStrings(buf *bytes.Buffer, values []string) {
+	offsets := make([]uint32, len(values)+1)
+	for i, v := range values {
+		offsets[i+1] = offsets[i] + uint32(len(v))
+	}
+	write(buf, offsets)
+	for _, v := range values {
+		buf.WriteString(v)
+	}
+	for buf.Len()%4 != 0 {
+		buf.WriteByte(0)
+	}
+}
+
+// DecodeNodes reads a nodes message and adds the origin back.
+func DecodeNodes(b []byte) (gisapi.NodesData, error) {
+	r, h, err := open(b, KindNodes)
+	if err != nil {
+		return nil, err
+	}
+
+	n := int(h.Count)
+	ids, types := make([]int32, n), make([]int32, n)
+	positions := make([]float32, n*3)
+	if err := read(r, ids, types, positions); err != nil {
+		return nil, err
+	}
+	guids, err := readStrings(r, n)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(gisapi.NodesData, n)
+	for i := range nodes {
+		nodes[i] = gisapi.NodeGeometry{
+			ID:       int(ids[i]),
+			Guid:     guids[i],
+			Type:     int(types[i]),
+			Geometry: position(positions, i, h.Origin),
+		}
+	}
+
+	return nodes, nil
+}
+
+// DecodeLinks reads a links message and adds the origin back.
+func DecodeLinks(b []byte) (gisapi.LinksData, error) {
+	r, h, err := open(b, KindLinks)
+	if err != nil {
+		return nil, err
+	}
+
+	n := int(h.Count)
+	ids, types, sequences, starts, ends := make([]int32, n), make([]int32, n), make([]int32, n), make([]int32, n), make([]int32, n)
+	offsets := make([]uint32, n+1)
+	positions := make([]float32, int(h.Vertices)*3)
+	if err := read(r, ids, types, sequences, starts, ends, offsets, positions); err != nil {
+		return nil, err
+	}
+	guids, err := readStrings(r, n)
+	if err != nil {
+		return nil, err
+	}
+
+	links := make(gisapi.LinksData, n)
+	for i := range links {
+		if offsets[i] > offsets[i+1] || offsets[i+1] > h.Vertices {
+			return nil, ErrInvalidMessage
+		}
+		geometry := make([][]float64, 0, offsets[i+1]-offsets[i])
+		for v := offsets[i]; v < offsets[i+1]; v++ {
+			geometry = append(geometry, position(positions, int(v), h.Origin))
+		}
+		links[i] = gisapi.LinkGeometry{
+			ID:          int(ids[i]),
+			Guid:        guids[i],
+			SequenceNo:  int(sequences[i]),
+			StartNodeId: int(starts[i]),
+			EndNodeId:   int(ends[i]),
+			Type:        int(types[i]),
+			Geometry:    geometry,
+		}
+	}
+
+	return links, nil
+}
+
+func open(b []byte, kind uint32) (*bytes.Reader, header, error) {
+	var h header
+	r := bytes.NewReader(b)
+	if err := binary.Read(r, binary.LittleEndian, &h); err != nil || h.Magic != magic || h.Version != Version {
+		return nil, h, ErrInvalidMessage
+	}
+	if h.Kind != kind {
+		return nil, h, ErrKindMismatch
+	}
+	// Every element takes at least four bytes, which bounds the allocations below.
+	if uint64(h.Count)+uint64(h.Vertices) > uint64(len(b)) {
+		return nil, h, ErrInvalidMessage
+	}
+
+	return r, h, nil
+}
+
+func read(r *bytes.Reader, columns ...any) error {
+	for _, c := range columns {
+		if err := binary.Read(r, binary.LittleEndian, c); err != nil {
+			return ErrInvalidMessage
+		}
+	}
+
+	return nil
+}
+
+func readStrings(r *bytes.Reader, n int) ([]string, error) {
+	offsets := make([]uint32, n+1)
+	if err := read(r, offsets); err != nil {
+		return nil, err
+	}
+
+	if int64(offsets[n]) > int64(r.Len()) {
+		return nil, ErrInvalidMessage
+	}
+	data := make([]byte, offsets[n])
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, ErrInvalidMessage
+	}
+
+	values := make([]string, n)
+	for i := range values {
+		if offsets[i] > offsets[i+1] || offsets[i+1] > uint32(len(data)) {
+			return nil, ErrInvalidMessage
+		}
+		values[i] = string(data[offsets[i]:offsets[i+1]])
+	}
+
+	return values, nil
+}
+
+func position(positions []float32, i int, origin [3]float64) []float64 {
+	return []float64{
+		float64(positions[i*3]) + origin[0],
+		float64(positions[i*3+1]) + origin[1],
+		float64(positions[i*3+2]) + origin[2],
+	}
+}
@@ -0,0 +1,60 @@
+// Package wire
+// Created by RTT.
+// Author: teocci@yandex.com on 2025-4월-08
+package wire
+
+import (
+	"encoding/binary"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/teocci/go-hynix-3d-viewer/src/gisapi"
+)
+
+func TestNodesRoundTrip(t *testing.T) {
+	nodes := gisapi.NodesData{
+		{ID: 1, Guid: "a", Type: 3, Geometry: []float64{200100.5, 550200.25, 12}},
+		{ID: 2, Guid: "guid-2", Type: 4, Geometry: []float64{200101, 550201, 13.5}},
+	}
+	origin := []float64{200000, 550000, 0}
+
+	b := EncodeNodes(nodes, origin)
+	if len(b)%4 != 0 {
+		t.Errorf("Expected a 4-byte aligned message, got %d bytes", len(b))
+	}
+	if f := math.Float32frombits(binary.LittleEndian.Uint32(b[HeaderSize+16:])); f != 100.5 {
+		t.Errorf("Expected the first x relative to the origin, got %f", f)
+	}
+
+	got, err := DecodeNodes(b)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, nodes) {
+		t.Errorf("Expected %+v, got %+v", nodes, got)
+	}
+}
+
+func TestLinksRoundTrip(t *testing.T) {
+	links := gisapi.LinksData{
+		{ID: 10, Guid: "x", SequenceNo: 1, StartNodeId: 1, EndNodeId: 2, Type: 7, Geometry: [][]float64{{0, 0, 0}, {1, 2, 3}, {4, 5, 6}}},
+		{ID: 11, SequenceNo: 2, StartNodeId: 2, EndNodeId: 3, Type: 7, Geometry: [][]float64{}},
+		{ID: 12, Guid: "z", SequenceNo: 3, StartNodeId: 3, EndNodeId: 1, Type: 8, Geometry: [][]float64{{7, 8, 9}, {1, 1, 1}}},
+	}
+
+	got, err := DecodeLinks(EncodeLinks(links, nil))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, links) {
+		t.Errorf("Expected %+v, got %+v", links, got)
+	}
+
+	if _, err := DecodeNodes(EncodeLinks(links, nil)); err != ErrKindMismatch {
+		t.Errorf("Expected ErrKindMismatch, got %v", err)
+	}
+	if _, err := DecodeLinks([]byte("HNB1")); err != ErrInvalidMessage {
+		t.Errorf("Expected ErrInvalidMessage, got %v", err)
+	}
+}
@@ -128,12 +128,14 @@ export default class GISSceneBuilder {
     }
 
     /**
-     * Builds the 3D GIS network from the provided data
+     * Builds the 3D GIS network from the provided data.
+     * A network of a merged scene is placed at its offset.
      * @param {NetworkData} data - The network data to build
      */
     buildNetwork(data) {
-        const {nodes, links, uuid} = data
+        const {nodes, links, uuid, offset} = data
         const group = new THREE.Group()
+        if (!isNil(offset)) group.position.copy(asVector3(scaleGeometry(offset)))
 
         const nodeMap = new Map()
         const meshGroups = {
@@ -0,0 +1,188 @@
+/**
+ * Created by RTT.
+ * Author: teocci@yandex.com on 2025-4월-13
+ */
+
+import Restapi from '../restapi.js'
+
+const SORT_FIELDS = [
+    {value: 'name', label: 'Name'},
+    {value: '-updated', label: 'Last modified'},
+    {value: '-nodes', label: 'Most nodes'},
+    {value: '-links', label: 'Most links'},
+    {value: 'uuid', label: 'UUID'},
+]
+
+const PAGE_SIZE = 20
+const SEARCH_DELAY = 300
+
+export default class NetworkCatalogueComponent {
+    /** @type {HTMLDivElement} */
+    $element = null
+
+    /** @type {HTMLInputElement} */
+    $search = null
+
+    /** @type {HTMLSelectElement} */
+    $sort = null
+
+    /** @type {HTMLTableSectionElement} */
+    $rows = null
+
+    /** @type {HTMLDivElement} */
+    $pager = null
+
+    query = {q: '', sort: 'name', page: 1, size: PAGE_SIZE}
+
+    searchTimer = null
+
+    constructor($element) {
+        this.$element = $element
+        this.init()
+    }
+
+    init() {
+        const $wrapper = this.$element
+        if (!$wrapper) {
+            console.error('Catalogue container not found!')
+            return
+        }
+
+        $wrapper.innerHTML = ''
+
+        const $toolbar = document.createElement('div')
+        $toolbar.classList.add('catalogue-toolbar', 'd-flex', 'gap-2', 'mb-3')
+
+        this.$search = document.createElement('input')
+        this.$search.type = 'search'
+        this.$search.placeholder = 'Search by name or UUID'
+        this.$search.classList.add('form-control')
+        this.$search.oninput = () => this.onSearch()
+
+        this.$sort = document.createElement('select')
+        this.$sort.classList.add('form-select', 'w-auto')
+        for (const field of SORT_FIELDS) {
+            const $option = document.createElement('option')
+            $option.value = field.value
+            $option.textContent = field.label
+            this.$sort.append($option)
+        }
+        this.$sort.onchange = () => this.load({sort: this.$sort.value, page: 1})
+
+        $toolbar.append(this.$search, this.$sort)
+
+        const $table = document.createElement('table')
+        $table.classList.add('table', 'table-hover', 'catalogue-table')
+        $table.innerHTML = `
+            <thead>
+                <tr><th>Name</th><th>UUID</th><th class="text-end">Nodes</th><th class="text-end">Links</th><th>Last modified</th></tr>
+            </thead>`
+        this.$rows = document.createElement('tbody')
+        $table.append(this.$rows)
+
+        this.$pager = document.createElement('div')
+        this.$pager.classList.add('catalogue-pager', 'd-flex', 'align-items-center', 'gap-2')
+
+        $wrapper.append($toolbar, $table, this.$pager)
+    }
+
+    onSearch() {
+        clearTimeout(this.searchTimer)
+        this.searchTimer = setTimeout(() => this.load({q: this.$search.value.trim(), page: 1}), SEARCH_DELAY)
+    }
+
+    /**
+     * Fetch and render a page of the catalogue.
+     * @param {Object} [changes] - Query fields to change before loading.
+     */
+    async load(changes = {}) {
+        this.query = {...this.query, ...changes}
+
+        try {
+            const page = await Restapi.fetchNetworks(this.query)
+            this.render(page)
+        } catch (error) {
+            console.error(error)
+            this.$rows.innerHTML = '<tr><td colspan="5" class="text-danger">Could not load the networks.</td></tr>'
+            this.$pager.innerHTML = ''
+        }
+    }
+
+    /**
+     * @param {NetworkPageData} page
+     */
+    render(page) {
+        this.$rows.innerHTML = ''
+        if (isNilArray(page.data)) {
+            this.$rows.innerHTML = '<tr><td colspan="5" class="text-secondary">No networks found.</td></tr>'
+        }
+
+        for (const network of page.data ?? []) {
+            this.$rows.append(this.createRow(network))
+        }
+
+        this.renderPager(page)
+    }
+
+    /**
+     * @param {NetworkInfoData} network
+     * @return {HTMLTableRowElement}
+     */
+    createRow(network) {
+        const $row = document.createElement('tr')
+        $row.style.cursor = 'pointer'
+        $row.dataset.uuid = network.uuid
+        $row.onclick = () => this.open(network.uuid)
+
+        const cells = [
+            network.name || `network-${shortUUID(network.uuid)}`,
+            network.uuid,
+            network.nodeCount.toLocaleString(),
+            network.linkCount.toLocaleString(),
+            isNilString(network.updatedAt) ? '' : new Date(network.updatedAt).toLocaleString(),
+        ]
+        cells.forEach((text, i) => {
+            const $cell = document.createElement('td')
+            $cell.textContent = text
+            if (i === 1) $cell.classList.add('font-monospace', 'small')
+            if (i === 2 || i === 3) $cell.classList.add('text-end')
+            $row.append($cell)
+        })
+
+        return $row
+    }
+
+    /**
+     * @param {NetworkPageData} page
+     */
+    renderPager(page) {
+        this.$pager.innerHTML = ''
+
+        const pages = Math.max(1, Math.ceil(page.total / page.size))
+
+        const $prev = this.createPageButton('Previous', page.page > 1, page.page - 1)
+        const $next = this.createPageButton('Next', page.page < pages, page.page + 1)
+
+        const $info = document.createElement('span')
+        $info.classList.add('text-secondary')
+        $info.textContent = `Page ${page.page} of ${pages} · ${page.total} networks`
+
+        this.$pager.append($prev, $info, $next)
+    }
+
+    createPageButton(label, enabled, page) {
+        const $button = document.createElement('button')
+        $button.type = 'button'
+        $button.classList.add('btn', 'btn-sm', 'btn-outline-secondary')
+        $button.textContent = label
+        $button.disabled = !enabled
+        $button.onclick = () => this.load({page})
+
+        return $button
+    }
+
+    open(uuid) {
+        const params = new URLSearchParams({network: uuid})
+        window.location.href = `/page/viewer?${params.toString()}`
+    }
+}
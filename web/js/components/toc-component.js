@@ -67,7 +67,7 @@ export default class TOCComponent {
 
         const collection = {
             uuid: data.uuid,
-            name: `network-${shortUUID(data.uuid)}`,
+            name: data.name || `network-${shortUUID(data.uuid)}`,
             gis: {},
         }
 
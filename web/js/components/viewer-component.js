@@ -851,6 +851,16 @@ export default class ViewerComponent {
         this.gisBuilder.buildNetwork(data)
     }
 
+    /**
+     * Loads the networks of a merged scene, each at its offset, and frames them all.
+     * @param {SceneData} scene - The scene manifest with the geometry of every network.
+     */
+    loadScene(scene) {
+        scene.networks.forEach(network => this.gisBuilder.buildNetwork(network))
+        this.updateModelCenterSize()
+        this.centerCameraOnModel()
+    }
+
     /**
      * Builds the 3D GIS scene based on the provided data.
      * @param {GISCollectionData[]} data - The GIS data containing points, lines, polylines, and polygons.
@@ -3,7 +3,15 @@
  * Author: teocci@yandex.com on 2022-6월-10
  */
 
+import NetworkCatalogueComponent from './components/network-catalogue-component.js'
+
 window.onload = () => {
     console.log('init')
     console.log('dashboard')
-}
\ No newline at end of file
+
+    const $catalogue = document.getElementById('catalogue')
+    if ($catalogue == null) throw new Error('Catalogue element not found.')
+
+    const catalogue = new NetworkCatalogueComponent($catalogue)
+    catalogue.load()
+}
@@ -71,6 +71,9 @@ export default class ViewerModule extends BaseComponent {
             case 'network':
                 this.loadNetwork()
                 break
+            case 'scene':
+                this.loadScene()
+                break
             case 'collections':
                 this.loadCollections()
                 break
@@ -95,6 +98,25 @@ export default class ViewerModule extends BaseComponent {
         })
     }
 
+    /**
+     * Loads several networks, possibly of different profiles, merged into one scene.
+     * Each network is built as its own group placed at its offset.
+     */
+    loadScene() {
+        const asyncScene = async () => {
+            console.log('Loading Scene data...')
+
+            const networks = pageInfo.params?.networks ?? null
+            return await Restapi.fetchScene(networks)
+        }
+
+        asyncScene().then(scene => {
+            console.log({scene})
+            this.viewer.loadScene(scene)
+            scene.networks.forEach(network => this.toc.loadNetwork(network))
+        })
+    }
+
     /**
      * Loads GIS data and passes it to the provided viewer instance.
      * If external data cannot be loaded, sample data is used.
@@ -84,6 +84,48 @@
  * @property {string} uuid - The universally unique identifier for the network.
  * @property {NodeGeometryData[]} nodes - The list of nodes in the network.
  * @property {LinkGeometryData[]} links - The list of links in the network.
+ * @property {string} [name] - The name of the network, when known.
+ * @property {number[]} [offset] - Where the network origin sits in a merged scene.
+ */
+
+/**
+ * @typedef {Object} SceneNetworkData
+ * @property {string} profile - The profile serving the network.
+ * @property {string} uuid - The universally unique identifier for the network.
+ * @property {string} [name] - The name of the network, when known.
+ * @property {number[]} offset - Where the network origin sits in the scene.
+ * @property {number[]} bbox - [minX, minY, minZ, maxX, maxY, maxZ] in scene coordinates.
+ * @property {{nodes: number, links: number}} idOffset - Added to the ids to keep them unique in the scene.
+ * @property {number} nodeCount - The number of nodes.
+ * @property {number} linkCount - The number of links.
+ * @property {NodeGeometryData[]} [nodes] - The nodes, relative to the network origin.
+ * @property {LinkGeometryData[]} [links] - The links, relative to the network origin.
+ */
+
+/**
+ * @typedef {Object} SceneData
+ * @property {string} [crs] - The coordinate reference system of the scene.
+ * @property {number[]} origin - The scene origin in that system.
+ * @property {number[]} bbox - The bounds of every network in scene coordinates.
+ * @property {SceneNetworkData[]} networks - The merged networks.
+ */
+
+/**
+ * @typedef {Object} NetworkInfoData
+ * @property {string} uuid - The universally unique identifier for the network.
+ * @property {string} name - The name of the network.
+ * @property {number} nodeCount - The number of nodes.
+ * @property {number} linkCount - The number of links.
+ * @property {?number[]} bbox - The [minX, minY, minZ, maxX, maxY, maxZ] bounds of the network.
+ * @property {string} updatedAt - When the network was last modified, as an RFC 3339 date.
+ */
+
+/**
+ * @typedef {Object} NetworkPageData
+ * @property {NetworkInfoData[]} data - The networks of the page.
+ * @property {number} total - The number of networks matching the search.
+ * @property {number} page - The 1-based page number.
+ * @property {number} size - The page size.
  */
 
 /**
@@ -112,6 +154,109 @@ const genOptions = (method = 'POST', data) => ({
     body: isNil(data) ? '' : JSON.stringify(data),
 })
 
+const LOGIN_PAGE_PATH = '/page/login'
+const REFRESH_PATH = '/auth/refresh'
+
+/** @type {?Promise<boolean>} */
+let refreshing = null
+
+/**
+ * Refresh the session once for every request that found its access token expired.
+ * Concurrent callers share the same refresh, since a refresh token can only be used once.
+ * @return {Promise<boolean>} - Whether the session was refreshed.
+ */
+const refreshSession = () => {
+    refreshing = refreshing ?? fetch(REFRESH_PATH, {method: 'POST', credentials: 'include'})
+        .then(response => response.ok)
+        .catch(() => false)
+        .finally(() => {
+            refreshing = null
+        })
+
+    return refreshing
+}
+
+/**
+ * Fetch an API resource, refreshing the session and retrying once when the access token expired.
+ * The page goes back to the login page when the session cannot be refreshed.
+ * @param {RequestInfo} input
+ * @param {RequestInit} [init]
+ * @return {Promise<Response>}
+ */
+const authFetch = async (input, init = {}) => {
+    const response = await fetch(input, init)
+    if (response.status !== 401) return response
+
+    if (!await refreshSession()) {
+        window.location.assign(LOGIN_PAGE_PATH)
+        return response
+    }
+
+    return await fetch(input, init)
+}
+
+/**
+ * Media type of the columnar binary network encoding, see src/wire/wire.go for the layout.
+ * @type {string}
+ */
+const NETWORK_BINARY_MIME = 'application/vnd.hynix.network'
+const NETWORK_BINARY_HEADER_SIZE = 48
+
+/**
+ * @typedef {Object} NetworkBinaryData
+ * @property {string} kind - 'nodes' or 'links'.
+ * @property {number[]} origin - The [x, y, z] origin to add back to every position.
+ * @property {Int32Array} ids
+ * @property {Int32Array} types
+ * @property {Float32Array} positions - x, y, z triples relative to the origin, ready for a BufferAttribute.
+ * @property {?Int32Array} sequenceNos - Links only.
+ * @property {?Int32Array} startNodeIds - Links only.
+ * @property {?Int32Array} endNodeIds - Links only.
+ * @property {?Uint32Array} offsets - Links only: the first vertex of each link, plus the vertex count.
+ * @property {string[]} guids
+ */
+
+/**
+ * Decode a binary network message into typed array views over the same buffer.
+ * @param {ArrayBuffer} buffer
+ * @return {NetworkBinaryData}
+ */
+const decodeNetworkBinary = buffer => {
+    const view = new DataView(buffer)
+    const magic = String.fromCharCode(...new Uint8Array(buffer, 0, 4))
+    if (magic !== 'HNB1' || view.getUint32(4, true) !== 1) throw new Error('Not a binary network message')
+
+    const kind = view.getUint32(8, true) === 1 ? 'nodes' : 'links'
+    const count = view.getUint32(12, true)
+    const vertices = view.getUint32(16, true)
+    const origin = [view.getFloat64(24, true), view.getFloat64(32, true), view.getFloat64(40, true)]
+
+    let offset = NETWORK_BINARY_HEADER_SIZE
+    const column = (ArrayType, length) => {
+        const array = new ArrayType(buffer, offset, length)
+        offset += length * 4
+        return array
+    }
+
+    const data = {kind, origin}
+    data.ids = column(Int32Array, count)
+    data.types = column(Int32Array, count)
+    if (kind === 'links') {
+        data.sequenceNos = column(Int32Array, count)
+        data.startNodeIds = column(Int32Array, count)
+        data.endNodeIds = column(Int32Array, count)
+        data.offsets = column(Uint32Array, count + 1)
+    }
+    data.positions = column(Float32Array, vertices * 3)
+
+    const stringOffsets = column(Uint32Array, count + 1)
+    const bytes = new Uint8Array(buffer, offset, stringOffsets[count])
+    const decoder = new TextDecoder()
+    data.guids = Array.from({length: count}, (_, i) => decoder.decode(bytes.subarray(stringOffsets[i], stringOffsets[i + 1])))
+
+    return data
+}
+
 export default class Restapi {
     /**
      * @param {Object} payload
@@ -136,7 +281,7 @@ export default class Restapi {
 
     static async fetchLogout() {
         const url = '/api/v1/user/logout'
-        const response = await fetch(url)
+        const response = await authFetch(url)
         return await response.json()
     }
 
@@ -151,11 +296,50 @@ export default class Restapi {
         params.append('collections', collections.join(','))
 
         const url = `/api/v1/collections?${params.toString()}`
-        const response = await fetch(url)
+        const response = await authFetch(url)
+
+        return await response.json()
+    }
+
+    /**
+     * Fetch a page of the network catalogue.
+     * @param {Object} [query] - {q, sort, page, size, provider}; sort is name, uuid, nodes, links or updated,
+     *                           prefixed with '-' for descending order.
+     * @return {Promise<NetworkPageData>}
+     */
+    static async fetchNetworks(query = {}) {
+        const params = new URLSearchParams()
+        for (const [key, value] of Object.entries(query)) {
+            if (!isNil(value) && value !== '') params.append(key, value)
+        }
+
+        const url = `/api/v1/networks?${params.toString()}`
+        const response = await authFetch(url)
+        if (!response.ok) throw new Error(`Failed to fetch networks: ${response.statusText}`)
 
         return await response.json()
     }
 
+    /**
+     * Fetch several networks, possibly of different profiles, merged into one scene.
+     * @param {string[]} networks - The networks as uuid, for the active profile, or profile:uuid.
+     * @param {Object} [options] - {crs, geometry}; geometry false returns the manifest only.
+     * @return {Promise<SceneData>}
+     */
+    static async fetchScene(networks, options = {}) {
+        if (isNilArray(networks)) throw new Error('At least one network is required')
+
+        const params = new URLSearchParams({networks: networks.join(',')})
+        for (const [key, value] of Object.entries(options)) {
+            if (!isNil(value) && value !== '') params.append(key, value)
+        }
+
+        const url = `/api/v1/scene?${params.toString()}`
+        const {data} = await this.fetchStreamedData(url)
+
+        return data
+    }
+
     /**
      * Fetch Nodes and Links from the network with the provided UUID.
      * @param {string} uuid - The UUID of the network to fetch.
@@ -198,6 +382,132 @@ export default class Restapi {
         return await this.fetchStreamedData(url)
     }
 
+    /**
+     * Fetch nodes or links in the columnar binary encoding.
+     * @param {string} uuid - The UUID of the network to fetch.
+     * @param {string} kind - 'nodes' or 'links'.
+     * @return {Promise<NetworkBinaryData>}
+     */
+    static async fetchNetworkBinary(uuid, kind) {
+        if (isNilString(uuid)) throw new Error('Network UUID is required')
+
+        const url = `/api/v1/network/${uuid}/${kind}`
+        const response = await authFetch(url, {headers: {'Accept': NETWORK_BINARY_MIME}})
+        if (!response.ok) throw new Error(`Failed to fetch ${kind}: ${response.statusText}`)
+
+        return decodeNetworkBinary(await response.arrayBuffer())
+    }
+
+    /**
+     * Fetch the attributes of a node or a link.
+     * @param {string} uuid - The UUID of the network.
+     * @param {string} kind - 'nodes' or 'links'.
+     * @param {number|string} ref - The id or guid of the node or link.
+     * @return {Promise<Object>} - The response object; data holds the attributes.
+     */
+    static async fetchFeatureAttributes(uuid, kind, ref) {
+        if (isNilString(uuid)) throw new Error('Network UUID is required')
+        if (isNil(ref)) throw new Error('id or guid is required')
+
+        const url = `/api/v1/network/${uuid}/${kind}/${encodeURIComponent(ref)}`
+        const response = await authFetch(url)
+        if (!response.ok) throw new Error(`Failed to fetch attributes: ${response.statusText}`)
+
+        return await response.json()
+    }
+
+    /**
+     * Fetch the node and link types of a network with their name, colour, icon and count.
+     * @param {string} uuid - The UUID of the network.
+     * @param {boolean} [all=false] - Also list the described types the network does not use.
+     * @return {Promise<Object>} - The response object; data holds {nodes, links} legend entries.
+     */
+    static async fetchNetworkLegend(uuid, all = false) {
+        if (isNilString(uuid)) throw new Error('Network UUID is required')
+
+        const url = `/api/v1/network/${uuid}/legend${all ? '?all=true' : ''}`
+        const response = await authFetch(url)
+        if (!response.ok) throw new Error(`Failed to fetch legend: ${response.statusText}`)
+
+        return await response.json()
+    }
+
+    /**
+     * Fetch the attributes of a selection of nodes and links in one call.
+     * @param {string} uuid - The UUID of the network.
+     * @param {Object} selection - {nodes: {ids, guids}, links: {ids, guids}}; at most 1000 references.
+     * @return {Promise<Object>} - The response object; data holds {nodes, links}.
+     */
+    static async fetchNetworkAttributes(uuid, selection) {
+        if (isNilString(uuid)) throw new Error('Network UUID is required')
+        if (isNil(selection)) throw new Error('selection is not defined')
+
+        const url = `/api/v1/network/${uuid}/attributes`
+        const response = await authFetch(url, postOptions(selection))
+        if (!response.ok) throw new Error(`Failed to fetch attributes: ${response.statusText}`)
+
+        return await response.json()
+    }
+
+    /**
+     * Fetch the links of a network already merged into polylines by the server.
+     * @param {string} uuid - The UUID of the network to fetch.
+     * @return {Promise<Object>} - The response object; data holds {type, closed, linkIds, geometry} chains.
+     */
+    static async fetchNetworkChains(uuid) {
+        const url = `/api/v1/network/${uuid}/chains`
+
+        return await this.fetchStreamedData(url)
+    }
+
+    /**
+     * Fetch the nodes and links of a network inside a region.
+     * @param {string} uuid - The UUID of the network to query.
+     * @param {Object} query - The query parameters: {mode: 'bbox', bbox} | {mode: 'radius', center, radius} | {mode: 'nearest', point, k}.
+     *                         Coordinate arrays are sent comma-separated.
+     * @return {Promise<Object>} - The response object; data holds {nodes, links} and, for nearest, the ordered hits.
+     */
+    static async fetchNetworkQuery(uuid, query) {
+        if (isNilString(uuid)) throw new Error('Network UUID is required')
+        if (isNil(query)) throw new Error('query is not defined')
+
+        const params = new URLSearchParams()
+        Object.entries(query).forEach(([key, value]) => {
+            params.append(key, Array.isArray(value) ? value.join(',') : value)
+        })
+
+        const url = `/api/v1/network/${uuid}/query?${params.toString()}`
+
+        return await this.fetchStreamedData(url)
+    }
+
+    /**
+     * Fetch the tile manifest of a network: bounds, depth and every non-empty octree tile.
+     * @param {string} uuid - The UUID of the network.
+     * @return {Promise<Object>} - The response object.
+     */
+    static async fetchNetworkTileset(uuid) {
+        if (isNilString(uuid)) throw new Error('Network UUID is required')
+
+        const url = `/api/v1/network/${uuid}/tiles`
+
+        return await this.fetchStreamedData(url)
+    }
+
+    /**
+     * Fetch one tile; coarse levels hold simplified links and node clusters.
+     * @param {string} uuid - The UUID of the network.
+     * @param {{level: number, x: number, y: number, z: number}} tile - The tile address.
+     * @return {Promise<Object>} - The response object.
+     */
+    static async fetchNetworkTile(uuid, {level, x, y, z}) {
+        if (isNilString(uuid)) throw new Error('Network UUID is required')
+
+        const url = `/api/v1/network/${uuid}/tiles/${level}/${x}/${y}/${z}`
+
+        return await this.fetchStreamedData(url)
+    }
+
     /**
      * Helper function to fetch streamed JSON data
      * Handles progress tracking for large responses
@@ -218,7 +528,7 @@ export default class Restapi {
 
         try {
             // Start the fetch request
-            const response = await fetch(url, fetchOptions)
+            const response = await authFetch(url, fetchOptions)
 
             if (!response.ok) {
                 const errorData = await response.json()